@@ -0,0 +1,288 @@
+// Package avalanche adapts Heimdallr to the Avalanche C-Chain: it watches the
+// bridge contract for AVAX/USDC locks and submits the unlock transactions
+// that release them back to users.
+package avalanche
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+	"heimdallr/internal/bridge/federation"
+	"heimdallr/internal/fees"
+)
+
+const (
+	assetAVAX = "AVAX"
+	assetUSDC = "USDC"
+)
+
+// DefaultConfirmations is used for any asset not given an explicit entry in
+// SourceConfirmations.
+const DefaultConfirmations = 12
+
+// SourceConfirmations configures, per asset symbol, how many C-Chain blocks
+// must bury a lock event before it is forwarded to the bridge. This guards
+// against a re-org dropping the source transaction after we've already
+// minted the wrapped asset on Tezos.
+type SourceConfirmations map[string]uint64
+
+func (c SourceConfirmations) forAsset(asset string) uint64 {
+	if n, ok := c[asset]; ok {
+		return n
+	}
+	return DefaultConfirmations
+}
+
+// Config holds everything needed to talk to the C-Chain bridge contract.
+type Config struct {
+	RPCURL          string
+	ContractAddress common.Address
+	PrivateKey      string
+	ChainID         *big.Int
+
+	// SourceConfirmations overrides DefaultConfirmations per asset.
+	SourceConfirmations SourceConfirmations
+
+	// StartBlock overrides the LastProcessedBlock cursor on the next
+	// Subscribe call, for initial deployment or disaster recovery. Zero
+	// means "use the stored cursor".
+	StartBlock uint64 `env:"AVALANCHE_START_BLOCK" envDefault:"0"`
+
+	// FeeCeiling caps maxFeePerGas an unlock will ever offer, regardless of
+	// what the suggested tip and base fee imply. Nil means no cap.
+	FeeCeiling *big.Int
+
+	// ReplaceAfter is how many blocks a submitted unlock may sit unconfirmed
+	// before it's rebroadcast at a bumped fee. Zero means
+	// fees.DefaultReplaceAfter.
+	ReplaceAfter uint64
+
+	// MaxReplacements bounds how many times one unlock is rebroadcast before
+	// giving up. Zero means fees.DefaultMaxReplacements.
+	MaxReplacements int
+}
+
+// Avalanche is the Heimdallr adapter for the Avalanche C-Chain.
+type Avalanche struct {
+	client        *ethclient.Client
+	contract      *bind.BoundContract
+	contractAddr  common.Address
+	abi           abi.ABI
+	signer        *bind.TransactOpts
+	confirmations SourceConfirmations
+	startBlock    uint64
+
+	feeCeiling  *big.Int
+	replacement fees.Config
+
+	logger *zap.SugaredLogger
+}
+
+// bridgeABI describes only the events and methods Heimdallr cares about.
+const bridgeABI = `[
+	{"type":"event","name":"AVAXLocked","inputs":[{"name":"user","type":"address","indexed":true},{"name":"amount","type":"uint256"},{"name":"destination","type":"string"}]},
+	{"type":"event","name":"USDCLocked","inputs":[{"name":"user","type":"address","indexed":true},{"name":"amount","type":"uint256"},{"name":"destination","type":"string"}]},
+	{"type":"function","name":"unlockAVAX","inputs":[{"name":"destination","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"unlockUSDC","inputs":[{"name":"destination","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"unlockAVAXWithApprovals","inputs":[{"name":"destination","type":"address"},{"name":"amount","type":"uint256"},{"name":"approvals","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"unlockUSDCWithApprovals","inputs":[{"name":"destination","type":"address"},{"name":"amount","type":"uint256"},{"name":"approvals","type":"bytes"}],"outputs":[]}
+]`
+
+// New connects to the C-Chain RPC endpoint and prepares the bridge contract
+// binding used for both subscribing to locks and submitting unlocks.
+func New(ctx context.Context, cfg Config, logger *zap.SugaredLogger) (*Avalanche, error) {
+	client, err := ethclient.DialContext(ctx, cfg.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("dial c-chain rpc: %w", err)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(bridgeABI))
+	if err != nil {
+		return nil, fmt.Errorf("parse bridge abi: %w", err)
+	}
+
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.PrivateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+
+	signer, err := bind.NewKeyedTransactorWithChainID(key, cfg.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("build transactor: %w", err)
+	}
+
+	contract := bind.NewBoundContract(cfg.ContractAddress, parsed, client, client, client)
+
+	return &Avalanche{
+		client:        client,
+		contract:      contract,
+		contractAddr:  cfg.ContractAddress,
+		abi:           parsed,
+		signer:        signer,
+		confirmations: cfg.SourceConfirmations,
+		startBlock:    cfg.StartBlock,
+		feeCeiling:    cfg.FeeCeiling,
+		replacement: fees.Config{
+			ReplaceAfter:    cfg.ReplaceAfter,
+			MaxReplacements: cfg.MaxReplacements,
+			Ceiling:         cfg.FeeCeiling,
+		},
+		logger: logger,
+	}, nil
+}
+
+// Name identifies this adapter to AssetRoute configuration and cursor keys.
+func (a *Avalanche) Name() string { return "avalanche" }
+
+// StartBlockOverride returns the operator-configured block to backfill
+// Subscribe from, bypassing the stored LastProcessedBlock cursor. Zero means
+// no override was configured.
+func (a *Avalanche) StartBlockOverride() uint64 {
+	return a.startBlock
+}
+
+func (a *Avalanche) filterQuery() ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{a.contractAddr},
+		Topics: [][]common.Hash{{
+			a.abi.Events["AVAXLocked"].ID,
+			a.abi.Events["USDCLocked"].ID,
+		}},
+	}
+}
+
+func (a *Avalanche) decodeLog(log types.Log) (LockEvent, string, error) {
+	var asset string
+	switch log.Topics[0] {
+	case a.abi.Events["AVAXLocked"].ID:
+		asset = assetAVAX
+	case a.abi.Events["USDCLocked"].ID:
+		asset = assetUSDC
+	default:
+		return LockEvent{}, "", fmt.Errorf("unrecognized log topic %s", log.Topics[0])
+	}
+
+	var decoded struct {
+		Amount      *big.Int
+		Destination string
+	}
+	if err := a.abi.UnpackIntoInterface(&decoded, asset+"Locked", log.Data); err != nil {
+		return LockEvent{}, "", fmt.Errorf("unpack %s log: %w", asset, err)
+	}
+
+	user := common.HexToAddress(log.Topics[1].Hex())
+
+	return LockEvent{
+		asset:       asset,
+		user:        user.Hex(),
+		amount:      decoded.Amount,
+		destination: decoded.Destination,
+		blockHash:   log.BlockHash,
+		blockNumber: log.BlockNumber,
+		logIndex:    log.Index,
+		txHash:      log.TxHash,
+	}, asset, nil
+}
+
+// Unlock releases previously-locked asset to destination on the C-Chain.
+// asset must be one the bridge contract supports ("AVAX" or "USDC").
+func (a *Avalanche) Unlock(ctx context.Context, asset, destination string, amount *big.Int) (string, *big.Int, error) {
+	method, err := unlockMethod(asset)
+	if err != nil {
+		return "", nil, err
+	}
+	return a.unlock(ctx, method, destination, amount)
+}
+
+// Mint always fails: the C-Chain side of the bridge only ever unlocks
+// natively-held assets, it never mints wrapped ones.
+func (a *Avalanche) Mint(ctx context.Context, asset string, amount *big.Int) (string, *big.Int, error) {
+	return "", nil, fmt.Errorf("avalanche adapter does not mint %q; it only unlocks", asset)
+}
+
+// Transfer always fails, for the same reason as Mint: nothing is ever
+// minted into this adapter's own custody to later transfer out. Unlock
+// already sends straight to destination in one call.
+func (a *Avalanche) Transfer(ctx context.Context, asset, destination string, amount *big.Int) (string, *big.Int, error) {
+	return "", nil, fmt.Errorf("avalanche adapter does not transfer %q; use Unlock", asset)
+}
+
+// Landed always reports false: the bridge contract's unlock methods don't
+// yet take an idempotency key, so there's nothing on-chain for this adapter
+// to recognize a prior attempt by. journaledStep falls back to its existing
+// journal-only behavior for this chain until the contract gains one.
+func (a *Avalanche) Landed(ctx context.Context, idempotencyKey string) (string, bool, error) {
+	return "", false, nil
+}
+
+func unlockMethod(asset string) (string, error) {
+	switch asset {
+	case assetAVAX:
+		return "unlockAVAX", nil
+	case assetUSDC:
+		return "unlockUSDC", nil
+	default:
+		return "", fmt.Errorf("avalanche adapter: unsupported asset %q", asset)
+	}
+}
+
+// unlock submits method(destination, amount) at a dynamically-estimated
+// EIP-1559 fee, rebroadcasting at a bumped fee via fees.SendWithReplacement
+// if it stalls, so a base fee spike can't leave it stuck forever.
+func (a *Avalanche) unlock(ctx context.Context, method, destination string, amount *big.Int) (string, *big.Int, error) {
+	dest := common.HexToAddress(destination)
+
+	nonce, err := a.client.PendingNonceAt(ctx, a.signer.From)
+	if err != nil {
+		return "", nil, fmt.Errorf("fetch nonce for %s: %w", method, err)
+	}
+
+	// A federation-approved swap carries its signed Bundle into the unlock
+	// call, so the approvals that authorized it are recorded alongside the
+	// transaction they authorized. Contract-side verification of the Ed25519
+	// signatures is out of scope here; today this is an on-chain audit
+	// trail, not a consensus check enforced by the EVM itself.
+	callMethod := method
+	callArgs := []interface{}{dest, amount}
+	if bundle, ok := federation.BundleFromContext(ctx); ok {
+		approvals, err := json.Marshal(bundle.Approvals)
+		if err != nil {
+			return "", nil, fmt.Errorf("marshal approvals for %s: %w", method, err)
+		}
+		callMethod = method + "WithApprovals"
+		callArgs = []interface{}{dest, amount, approvals}
+	}
+
+	initial, err := fees.EstimateEVM(ctx, a.client, a.feeCeiling)
+	if err != nil {
+		return "", nil, fmt.Errorf("estimate fees for %s: %w", method, err)
+	}
+
+	build := func(params fees.FeeParams) (fees.Tx, error) {
+		opts := *a.signer
+		opts.Context = ctx
+		opts.Nonce = new(big.Int).SetUint64(nonce)
+		opts.GasTipCap = params.EVM.TipCap
+		opts.GasFeeCap = params.EVM.FeeCap
+
+		tx, err := a.contract.Transact(&opts, callMethod, callArgs...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", callMethod, err)
+		}
+
+		return fees.EVMTx{Transaction: tx, Client: a.client}, nil
+	}
+
+	return fees.SendWithReplacement(ctx, a.replacement, fees.WaitNextEVMBlock(a.client), fees.FeeParams{EVM: &initial}, build)
+}
@@ -0,0 +1,51 @@
+package avalanche
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LockEvent is emitted when a user locks AVAX or USDC in the bridge contract
+// on the C-Chain. It satisfies bridge.Event structurally so it can be handed
+// straight to the bridge's Atomic operations without this package importing
+// bridge.
+type LockEvent struct {
+	asset       string
+	user        string
+	amount      *big.Int
+	destination string
+
+	blockHash   common.Hash
+	blockNumber uint64
+	logIndex    uint
+	txHash      common.Hash
+}
+
+func (e LockEvent) User() string        { return e.user }
+func (e LockEvent) Amount() *big.Int    { return e.amount }
+func (e LockEvent) Destination() string { return e.destination }
+
+// Asset is the symbol that was locked, e.g. "AVAX" or "USDC".
+func (e LockEvent) Asset() string { return e.asset }
+
+// Key returns a stable idempotency key for this lock, derived from the
+// source tx hash and log index, so the same event always journals to the
+// same swap even if it is redelivered or replayed.
+func (e LockEvent) Key() string {
+	return fmt.Sprintf("avalanche:%s:%d", e.txHash.Hex(), e.logIndex)
+}
+
+// Position is the C-Chain block this lock was included in, used to advance
+// the per-asset LastProcessedBlock cursor once its Operation completes.
+func (e LockEvent) Position() uint64 {
+	return e.blockNumber
+}
+
+// key identifies the log a LockEvent was decoded from. It is stable across
+// re-orgs that don't touch this exact block, and is how the subscription
+// tracks which buffered events survive to confirmation depth.
+func (e LockEvent) key() pendingKey {
+	return pendingKey{blockHash: e.blockHash, logIndex: e.logIndex}
+}
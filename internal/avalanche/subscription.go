@@ -0,0 +1,194 @@
+package avalanche
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// pollInterval is how often the subscription checks whether buffered events
+// have been buried deep enough, or re-orged out, since the C-Chain exposes no
+// "finalized" notification of its own.
+const pollInterval = 2 * time.Second
+
+// pendingKey identifies a log within the chain's history. A re-org that
+// replaces a block invalidates every pendingKey minted against its hash,
+// which is exactly the property we need to safely drop orphaned events.
+type pendingKey struct {
+	blockHash common.Hash
+	logIndex  uint
+}
+
+type pendingEvent struct {
+	asset string
+	event LockEvent
+}
+
+// Subscription streams confirmed lock events off the C-Chain. Raw logs are
+// buffered in-memory until SourceConfirmations[asset] blocks have buried
+// them; only then are they forwarded on Locked, each tagged with its asset.
+// Events whose block is re-orged away are dropped and reported on Err()
+// instead.
+type Subscription struct {
+	locked chan LockEvent
+	err    chan error
+
+	logsSub ethereum.Subscription
+	cancel  context.CancelFunc
+}
+
+func (s *Subscription) Locked() <-chan LockEvent { return s.locked }
+func (s *Subscription) Err() <-chan error         { return s.err }
+
+// Close tears down the underlying log subscription and confirmation poller.
+func (s *Subscription) Close() {
+	s.cancel()
+	s.logsSub.Unsubscribe()
+}
+
+// Subscribe streams lock events off the C-Chain. If from is non-zero, it
+// first backfills every AVAXLocked/USDCLocked log between from and the
+// current head via FilterLogs, so downtime doesn't silently drop events,
+// before merging into the live subscription.
+func (a *Avalanche) Subscribe(ctx context.Context, from uint64) (*Subscription, error) {
+	logs := make(chan types.Log, 256)
+	logsSub, err := a.client.SubscribeFilterLogs(ctx, a.filterQuery(), logs)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe filter logs: %w", err)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	sub := &Subscription{
+		locked:  make(chan LockEvent),
+		err:     make(chan error, 1),
+		logsSub: logsSub,
+		cancel:  cancel,
+	}
+
+	backfill, err := a.backfill(ctx, from)
+	if err != nil {
+		cancel()
+		logsSub.Unsubscribe()
+		return nil, fmt.Errorf("backfill lock events: %w", err)
+	}
+
+	go a.run(subCtx, sub, backfill, logs)
+
+	return sub, nil
+}
+
+// backfill fetches every lock log between from and the current head. It
+// returns nil without querying anything if from is zero, meaning the caller
+// has no prior cursor and wants to start at the chain tip.
+func (a *Avalanche) backfill(ctx context.Context, from uint64) ([]types.Log, error) {
+	if from == 0 {
+		return nil, nil
+	}
+
+	head, err := a.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch head header: %w", err)
+	}
+	if from > head.Number.Uint64() {
+		return nil, nil
+	}
+
+	query := a.filterQuery()
+	query.FromBlock = new(big.Int).SetUint64(from)
+	query.ToBlock = head.Number
+
+	logs, err := a.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("filter logs from %d to %d: %w", from, head.Number.Uint64(), err)
+	}
+
+	return logs, nil
+}
+
+// run decodes raw logs into pending events and releases them once they're
+// buried by the configured confirmation depth, or drops them and reports a
+// re-org if the block that contained them stops being canonical. backfill is
+// processed before the live logs channel is drained at all.
+func (a *Avalanche) run(ctx context.Context, sub *Subscription, backfill []types.Log, logs <-chan types.Log) {
+	pending := make(map[pendingKey]pendingEvent)
+
+	for _, log := range backfill {
+		a.bufferLog(pending, log)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case err := <-sub.logsSub.Err():
+			if err != nil {
+				sub.err <- fmt.Errorf("log subscription: %w", err)
+			}
+
+		case log := <-logs:
+			a.bufferLog(pending, log)
+
+		case <-ticker.C:
+			a.releaseConfirmed(ctx, sub, pending)
+		}
+	}
+}
+
+func (a *Avalanche) bufferLog(pending map[pendingKey]pendingEvent, log types.Log) {
+	event, asset, err := a.decodeLog(log)
+	if err != nil {
+		a.logger.Errorf("decode lock log: %s", err)
+		return
+	}
+
+	if log.Removed {
+		// Already known to be re-orged out before we even buffered it.
+		return
+	}
+
+	pending[event.key()] = pendingEvent{asset: asset, event: event}
+}
+
+func (a *Avalanche) releaseConfirmed(ctx context.Context, sub *Subscription, pending map[pendingKey]pendingEvent) {
+	head, err := a.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		a.logger.Errorf("fetch head header: %s", err)
+		return
+	}
+
+	for key, pe := range pending {
+		required := a.confirmations.forAsset(pe.asset)
+		if head.Number.Uint64() < pe.event.blockNumber+required {
+			continue
+		}
+
+		canonical, err := a.client.HeaderByNumber(ctx, new(big.Int).SetUint64(pe.event.blockNumber))
+		if err != nil {
+			a.logger.Errorf("fetch header %d: %s", pe.event.blockNumber, err)
+			continue
+		}
+
+		if canonical.Hash() != key.blockHash {
+			a.logger.Warnw("lock event re-orged out, dropping",
+				"asset", pe.asset,
+				"tx_hash", pe.event.txHash.Hex(),
+				"block", pe.event.blockNumber,
+			)
+			sub.err <- fmt.Errorf("reorged: %s lock at block %d (tx %s) is no longer canonical", pe.asset, pe.event.blockNumber, pe.event.txHash.Hex())
+			delete(pending, key)
+			continue
+		}
+
+		sub.locked <- pe.event
+		delete(pending, key)
+	}
+}
@@ -0,0 +1,92 @@
+package bridge
+
+import (
+	"context"
+	"math/big"
+)
+
+// ChainAdapter is everything Bridge needs from one chain: a stream of
+// confirmed lock/burn events, and the write paths that credit a user on this
+// chain once a swap from elsewhere has been approved. Avalanche, Tezos, and
+// any future chain (Ethereum, BSC, ...) all plug in behind this same
+// interface, with AssetRoute deciding which adapter plays which role in a
+// given swap.
+type ChainAdapter interface {
+	// Name identifies this adapter in AssetRoute configuration and cursor
+	// keys, e.g. "avalanche".
+	Name() string
+
+	// Subscribe streams this chain's confirmed events from block/level from
+	// onward (0 meaning "only the chain tip, no backfill"). The returned
+	// channels run until ctx is cancelled.
+	Subscribe(ctx context.Context, from uint64) (<-chan Event, <-chan error, error)
+
+	// StartOverride returns an operator-configured block/level to backfill
+	// Subscribe from, bypassing the stored cursor. Zero means no override.
+	StartOverride() uint64
+
+	// Mint credits amount of a wrapped asset into this adapter's own
+	// custody, ready for Transfer to hand to a user. Adapters that only
+	// ever unlock natively-held assets (because nothing is wrapped on their
+	// chain) reject every asset they're given.
+	Mint(ctx context.Context, asset string, amount *big.Int) (hash string, fee *big.Int, err error)
+
+	// Transfer sends a previously-minted balance to destination.
+	Transfer(ctx context.Context, asset, destination string, amount *big.Int) (hash string, fee *big.Int, err error)
+
+	// Unlock releases a previously-locked native asset straight to
+	// destination. Adapters that only ever mint wrapped assets reject every
+	// asset they're given.
+	Unlock(ctx context.Context, asset, destination string, amount *big.Int) (hash string, fee *big.Int, err error)
+
+	// Landed reports whether a Mint/Transfer/Unlock call tagged with
+	// idempotencyKey (see idempotency.WithKey) has already been recorded by
+	// this chain. journaledStep consults it before resubmitting a step, so
+	// a crash between a call broadcasting and the journal fsyncing its hash
+	// can't turn a replay into a double-mint or double-unlock. ok is false
+	// if no matching call has landed yet.
+	Landed(ctx context.Context, idempotencyKey string) (hash string, ok bool, err error)
+}
+
+// WrapMode says how an adapter credits a user with an asset: by minting a
+// wrapped representation of it, or by unlocking a natively-held balance.
+type WrapMode string
+
+const (
+	WrapMint   WrapMode = "mint"
+	WrapUnlock WrapMode = "unlock"
+)
+
+// AssetRoute describes one swap direction: an asset locked or burned on
+// SourceChain is credited as DestAsset on DestChain. Rollback describes how
+// the swap is undone on SourceChain if crediting DestChain fails.
+type AssetRoute struct {
+	// Name is this route's Operation name, e.g. "swap AVAX to WAVAX". It is
+	// what's journaled, so renaming a route breaks replay of any swap
+	// in flight under the old name.
+	Name string
+
+	SourceChain string
+	SourceAsset string
+	DestChain   string
+	DestAsset   string
+
+	// Wrap is how DestChain credits DestAsset to the swap's destination.
+	Wrap WrapMode
+
+	// Rollback is how SourceChain compensates with SourceAsset if crediting
+	// DestChain never succeeds.
+	Rollback WrapMode
+}
+
+// DefaultRoutes is the Avalanche<->Tezos route set Heimdallr has always run,
+// expressed as AssetRoutes. A deployment adding a third chain (e.g.
+// Ethereum) appends its own routes to this slice rather than replacing it.
+func DefaultRoutes() []AssetRoute {
+	return []AssetRoute{
+		{Name: "swap AVAX to WAVAX", SourceChain: "avalanche", SourceAsset: "AVAX", DestChain: "tezos", DestAsset: "WAVAX", Wrap: WrapMint, Rollback: WrapUnlock},
+		{Name: "swap USDC to WUSDC", SourceChain: "avalanche", SourceAsset: "USDC", DestChain: "tezos", DestAsset: "WUSDC", Wrap: WrapMint, Rollback: WrapUnlock},
+		{Name: "swap WAVAX to AVAX", SourceChain: "tezos", SourceAsset: "WAVAX", DestChain: "avalanche", DestAsset: "AVAX", Wrap: WrapUnlock, Rollback: WrapMint},
+		{Name: "swap WUSDC to USDC", SourceChain: "tezos", SourceAsset: "WUSDC", DestChain: "avalanche", DestAsset: "USDC", Wrap: WrapUnlock, Rollback: WrapMint},
+	}
+}
@@ -0,0 +1,299 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"heimdallr/internal/bridge/journal"
+)
+
+// StepFunc performs one leg of a swap (the forward mint/unlock, or its
+// rollback). steps lets it journal its own sub-steps so a crash mid-leg can
+// be resumed without re-submitting work that already landed on-chain.
+type StepFunc func(ctx context.Context, event Event, steps Recorder) bool
+
+// Recorder lets a StepFunc persist the destination tx hash of a named
+// sub-step, and check whether a prior attempt already recorded one.
+type Recorder interface {
+	// Done returns the destination tx hash a prior attempt recorded for
+	// step id, if any.
+	Done(id string) (hash string, ok bool)
+	// Record persists that step id produced hash/fee, fsyncing before
+	// returning so the caller can rely on it surviving a crash.
+	Record(id string, hash string, fee *big.Int) error
+}
+
+type operationTemplate struct {
+	perform  StepFunc
+	rollback StepFunc
+}
+
+type AtomicOption func(*Atomic)
+
+// WithChecker registers fn to observe how every Operation this Atomic
+// creates ends up (complete, rolled back, or failed).
+func WithChecker(fn func(Checker, Event)) AtomicOption {
+	return func(a *Atomic) { a.checker = fn }
+}
+
+// WithJournal persists every Operation's state transitions to j, making them
+// resumable via Recover after a crash. Without it, Atomic behaves exactly as
+// before: in-memory only.
+func WithJournal(j *journal.Journal) AtomicOption {
+	return func(a *Atomic) { a.journal = j }
+}
+
+// Atomic is the factory for swap Operations. All Operations it creates share
+// its checker and journal.
+type Atomic struct {
+	checker   func(Checker, Event)
+	journal   *journal.Journal
+	templates map[string]operationTemplate
+
+	mu     sync.Mutex
+	active map[journal.Key]bool
+}
+
+func NewAtomic(opts ...AtomicOption) *Atomic {
+	a := &Atomic{}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Register associates an Operation name with the perform/rollback pair that
+// implements it, so Recover can reconstruct and resume one found pending in
+// the journal without the caller having to re-derive it from the event.
+func (a *Atomic) Register(name string, perform, rollback StepFunc) {
+	if a.templates == nil {
+		a.templates = make(map[string]operationTemplate)
+	}
+	a.templates[name] = operationTemplate{perform: perform, rollback: rollback}
+}
+
+// Template returns the perform/rollback pair registered for name, so callers
+// that build live Operations can share the exact StepFuncs Recover would use
+// to resume one from the journal.
+func (a *Atomic) Template(name string) (perform, rollback StepFunc, ok bool) {
+	tmpl, ok := a.templates[name]
+	return tmpl.perform, tmpl.rollback, ok
+}
+
+// Recover replays the journal and resumes every Operation left in a
+// non-terminal state, e.g. by a crash between minting and transferring. It
+// must be called once, before the bridge starts handling new events.
+func (a *Atomic) Recover(ctx context.Context) error {
+	if a.journal == nil {
+		return nil
+	}
+
+	pending, err := a.journal.Pending()
+	if err != nil {
+		return fmt.Errorf("read pending journal records: %w", err)
+	}
+
+	for _, rec := range pending {
+		tmpl, ok := a.templates[rec.Operation]
+		if !ok {
+			return fmt.Errorf("no recovery template registered for operation %q (key %s)", rec.Operation, rec.Key)
+		}
+
+		if !a.claim(rec.Key) {
+			continue
+		}
+
+		op := a.NewOperation(WithName(rec.Operation), OnPerform(tmpl.perform), OnRollback(tmpl.rollback))
+		go func(rec journal.Record, op *Operation) {
+			defer a.release(rec.Key)
+			op.runFrom(ctx, eventFromRecord(rec), rec.State, rec.Steps)
+		}(rec, op)
+	}
+
+	return nil
+}
+
+// claim reports whether key isn't already being driven by another goroutine,
+// and if so marks it as claimed. A caller that loses the race must not act
+// on that key at all: release is owned by whichever goroutine's claim
+// succeeded.
+func (a *Atomic) claim(key journal.Key) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.active == nil {
+		a.active = make(map[journal.Key]bool)
+	}
+	if a.active[key] {
+		return false
+	}
+	a.active[key] = true
+	return true
+}
+
+func (a *Atomic) release(key journal.Key) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.active, key)
+}
+
+type OperationOption func(*Operation)
+
+func WithName(name string) OperationOption {
+	return func(op *Operation) { op.name = name }
+}
+
+func OnPerform(fn StepFunc) OperationOption {
+	return func(op *Operation) { op.perform = fn }
+}
+
+func OnRollback(fn StepFunc) OperationOption {
+	return func(op *Operation) { op.rollback = fn }
+}
+
+// Checker lets the bridge observe how an Operation ended, for logging.
+type Checker interface {
+	Name() string
+	Complete() <-chan struct{}
+	Rollback() <-chan struct{}
+	Fail() <-chan error
+}
+
+// Operation is one in-flight swap: perform the forward leg, and if it
+// fails, roll back the side effects already committed.
+type Operation struct {
+	name     string
+	perform  StepFunc
+	rollback StepFunc
+	atomic   *Atomic
+
+	completeCh chan struct{}
+	rollbackCh chan struct{}
+	failCh     chan error
+}
+
+func (a *Atomic) NewOperation(opts ...OperationOption) *Operation {
+	op := &Operation{
+		atomic:     a,
+		completeCh: make(chan struct{}),
+		rollbackCh: make(chan struct{}),
+		failCh:     make(chan error, 1),
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	return op
+}
+
+func (op *Operation) Name() string              { return op.name }
+func (op *Operation) Complete() <-chan struct{} { return op.completeCh }
+func (op *Operation) Rollback() <-chan struct{} { return op.rollbackCh }
+func (op *Operation) Fail() <-chan error        { return op.failCh }
+
+// Run performs the operation against event, journaling every step so it can
+// be resumed by Atomic.Recover if the process dies mid-flight.
+//
+// If event has already been journaled to a terminal state, Run skips
+// straight to reporting that outcome instead of re-performing it. This
+// makes it safe to redeliver the same event twice, which a historical
+// backfill can do whenever it overlaps blocks the bridge already processed.
+//
+// A non-terminal record means this key is already in flight, either because
+// Atomic.Recover is resuming it after a crash or because an earlier, still
+// running Run call is. Run claims the key before driving it so only one
+// goroutine ever acts on it at a time; losing the race means something else
+// owns it, so Run returns without starting a second, duplicate attempt.
+func (op *Operation) Run(ctx context.Context, event Event) {
+	key := journal.Key(event.Key())
+
+	if rec, ok := op.atomic.latestRecord(event); ok {
+		if rec.State.Terminal() {
+			op.reportTerminal(rec)
+			return
+		}
+
+		if !op.atomic.claim(key) {
+			return
+		}
+		defer op.atomic.release(key)
+
+		op.runFrom(ctx, event, rec.State, rec.Steps)
+		return
+	}
+
+	if !op.atomic.claim(key) {
+		return
+	}
+	defer op.atomic.release(key)
+
+	op.runFrom(ctx, event, journal.StateCreated, nil)
+}
+
+func (a *Atomic) latestRecord(event Event) (journal.Record, bool) {
+	if a.journal == nil {
+		return journal.Record{}, false
+	}
+
+	rec, ok, err := a.journal.Latest(journal.Key(event.Key()))
+	if err != nil || !ok {
+		return journal.Record{}, false
+	}
+
+	return rec, true
+}
+
+func (op *Operation) reportTerminal(rec journal.Record) {
+	switch rec.State {
+	case journal.StateComplete:
+		close(op.completeCh)
+	case journal.StateRolledBack:
+		close(op.rollbackCh)
+	default:
+		op.failCh <- fmt.Errorf("operation %q previously failed terminally (key %s)", op.name, rec.Key)
+	}
+}
+
+// runFrom drives the operation starting at start, which is journal.StateCreated
+// for a brand new swap or whatever state Recover found it stalled in.
+// priorSteps seeds the Recorder with sub-steps a previous attempt already
+// completed, so perform/rollback can skip re-submitting them.
+func (op *Operation) runFrom(ctx context.Context, event Event, start journal.State, priorSteps map[string]journal.Step) {
+	if op.atomic.checker != nil {
+		go op.atomic.checker(op, event)
+	}
+
+	steps := op.atomic.recorderFor(event, op.name)
+	if priorSteps != nil {
+		steps.steps = priorSteps
+	}
+
+	if start != journal.StateRollback {
+		steps.append(journal.StateCreated, "")
+
+		if op.perform(ctx, event, steps) {
+			steps.append(journal.StateComplete, "")
+			close(op.completeCh)
+			return
+		}
+
+		if op.rollback == nil {
+			steps.append(journal.StateFailed, "no rollback defined")
+			op.failCh <- fmt.Errorf("operation %q failed with no rollback", op.name)
+			return
+		}
+
+		steps.append(journal.StateRollback, "")
+	}
+
+	if op.rollback(ctx, event, steps) {
+		steps.append(journal.StateRolledBack, "")
+		close(op.rollbackCh)
+		return
+	}
+
+	steps.append(journal.StateFailed, "rollback also failed")
+	op.failCh <- fmt.Errorf("operation %q failed and could not be rolled back", op.name)
+}
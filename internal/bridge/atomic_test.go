@@ -0,0 +1,74 @@
+package bridge
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"heimdallr/internal/bridge/journal"
+)
+
+type testEvent struct {
+	key string
+}
+
+func (e testEvent) User() string        { return "user" }
+func (e testEvent) Amount() *big.Int    { return big.NewInt(1) }
+func (e testEvent) Destination() string { return "dest" }
+func (e testEvent) Asset() string       { return "WAVAX" }
+func (e testEvent) Key() string         { return e.key }
+func (e testEvent) Position() uint64    { return 1 }
+
+// TestRunDoesNotDuplicateAnInFlightOperation guards against the double-mint
+// race this test is named for: a crash leaves a non-terminal journal record,
+// Recover resumes it, and a historical backfill redelivers the exact same
+// source event into Run before Recover's goroutine finishes. Run must not
+// spawn a second perform() for the same key while the first is still going.
+func TestRunDoesNotDuplicateAnInFlightOperation(t *testing.T) {
+	j, err := journal.Open(filepath.Join(t.TempDir(), "journal"))
+	require.NoError(t, err)
+	defer j.Close()
+
+	require.NoError(t, j.Append(journal.Record{
+		Key:       "k1",
+		Operation: "test",
+		State:     journal.StatePerformed,
+		Amount:    "1",
+		Steps:     map[string]journal.Step{"mint": {TxHash: "0xmint"}},
+		Time:      time.Now(),
+	}))
+
+	var performCalls int32
+	unblock := make(chan struct{})
+	perform := func(ctx context.Context, event Event, steps Recorder) bool {
+		atomic.AddInt32(&performCalls, 1)
+		<-unblock
+		return true
+	}
+
+	a := NewAtomic(WithJournal(j))
+	a.Register("test", perform, nil)
+
+	require.NoError(t, a.Recover(context.Background()))
+
+	// Recover claims "k1" synchronously before returning, so this redelivery
+	// is guaranteed to observe the claim already held.
+	redelivered := a.NewOperation(WithName("test"), OnPerform(perform))
+	redelivered.Run(context.Background(), testEvent{key: "k1"})
+
+	// Recover's goroutine calls perform asynchronously; wait for it to start
+	// before counting calls.
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&performCalls) >= 1
+	}, time.Second, time.Millisecond)
+	require.EqualValues(t, 1, atomic.LoadInt32(&performCalls))
+
+	close(unblock)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&performCalls) == 1
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,67 @@
+package bridge
+
+import (
+	"context"
+	"math/big"
+
+	"heimdallr/internal/avalanche"
+)
+
+// avalancheAdapter makes *avalanche.Avalanche satisfy ChainAdapter. It lives
+// here rather than in package avalanche because it has to convert
+// avalanche.LockEvent's own channel into a <-chan Event, which requires
+// importing this package's Event type; avalanche can't import bridge without
+// creating a cycle.
+type avalancheAdapter struct {
+	*avalanche.Avalanche
+}
+
+// NewAvalancheAdapter wraps ava so it can be passed to Bridge.New.
+func NewAvalancheAdapter(ava *avalanche.Avalanche) ChainAdapter {
+	return avalancheAdapter{ava}
+}
+
+func (a avalancheAdapter) StartOverride() uint64 {
+	return a.Avalanche.StartBlockOverride()
+}
+
+func (a avalancheAdapter) Subscribe(ctx context.Context, from uint64) (<-chan Event, <-chan error, error) {
+	sub, err := a.Avalanche.Subscribe(ctx, from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-sub.Locked():
+				if !ok {
+					return
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, sub.Err(), nil
+}
+
+func (a avalancheAdapter) Mint(ctx context.Context, asset string, amount *big.Int) (string, *big.Int, error) {
+	return a.Avalanche.Mint(ctx, asset, amount)
+}
+
+func (a avalancheAdapter) Transfer(ctx context.Context, asset, destination string, amount *big.Int) (string, *big.Int, error) {
+	return a.Avalanche.Transfer(ctx, asset, destination, amount)
+}
+
+func (a avalancheAdapter) Unlock(ctx context.Context, asset, destination string, amount *big.Int) (string, *big.Int, error) {
+	return a.Avalanche.Unlock(ctx, asset, destination, amount)
+}
@@ -4,139 +4,321 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"reflect"
+	"sync"
 
 	"go.uber.org/zap"
-	"heimdallr/internal/avalanche"
-	"heimdallr/internal/tezos"
+	"heimdallr/internal/bridge/cursor"
+	"heimdallr/internal/bridge/federation"
+	"heimdallr/internal/bridge/idempotency"
+	"heimdallr/internal/bridge/journal"
 )
 
 type Bridge struct {
-	avalanche *avalanche.Avalanche
-	tezos     *tezos.Tezos
+	adapters map[string]ChainAdapter
+	routes   map[string]AssetRoute            // by route Name
+	byAsset  map[string]map[string]AssetRoute // by SourceChain, then SourceAsset
+
+	journal *journal.Journal
+	cursor  *cursor.Store
+
+	// federation gates every mint/unlock behind M-of-N peer approval and
+	// picks which node actually submits it, if configured. Nil means this
+	// node signs and submits alone, as it always has.
+	federation *federation.Coordinator
+
+	// observedMu guards observed, which federation.Validator consults: the
+	// events this node has itself seen on a source chain, so it never
+	// counter-signs a peer's proposal it can't verify independently.
+	observedMu sync.Mutex
+	observed   map[observedKey]observedEvent
 
 	logger *zap.SugaredLogger
 }
 
+// observedKey identifies a source event this node might be asked to
+// counter-sign a proposal for: the label the proposal was built under (e.g.
+// "swap AVAX to WAVAX:perform") plus the event's own idempotency key.
+type observedKey struct {
+	operation string
+	source    string
+}
+
+// observedEvent is the part of a previously-observed event a
+// federation.Proposal must match for this node to counter-sign it.
+type observedEvent struct {
+	amount      *big.Int
+	destination string
+}
+
 type Event interface {
 	User() string
 	Amount() *big.Int
 	Destination() string
+
+	// Asset is the symbol this event moved (e.g. "AVAX", "WUSDC"), used to
+	// look up which AssetRoute handles it.
+	Asset() string
+
+	// Key is a stable idempotency key for this event (source tx hash + log
+	// index, or equivalent), used to journal and replay its Operation.
+	Key() string
+
+	// Position is where on the source chain this event was found (block
+	// number or level), used to advance the LastProcessedBlock cursor once
+	// its Operation reaches a terminal state.
+	Position() uint64
+}
+
+// New builds a Bridge over adapters, wired up according to routes. federation
+// may be nil, in which case Bridge performs every mint/unlock itself exactly
+// as before.
+func New(adapters []ChainAdapter, routes []AssetRoute, journal *journal.Journal, cursor *cursor.Store, fed *federation.Coordinator, logger *zap.SugaredLogger) (*Bridge, error) {
+	byName := make(map[string]ChainAdapter, len(adapters))
+	for _, a := range adapters {
+		byName[a.Name()] = a
+	}
+
+	byRoute := make(map[string]AssetRoute, len(routes))
+	byAsset := make(map[string]map[string]AssetRoute, len(adapters))
+	for _, route := range routes {
+		if _, ok := byName[route.SourceChain]; !ok {
+			return nil, fmt.Errorf("route %q: unknown source chain %q", route.Name, route.SourceChain)
+		}
+		if _, ok := byName[route.DestChain]; !ok {
+			return nil, fmt.Errorf("route %q: unknown dest chain %q", route.Name, route.DestChain)
+		}
+
+		byRoute[route.Name] = route
+
+		if byAsset[route.SourceChain] == nil {
+			byAsset[route.SourceChain] = make(map[string]AssetRoute)
+		}
+		byAsset[route.SourceChain][route.SourceAsset] = route
+	}
+
+	b := &Bridge{
+		adapters:   byName,
+		routes:     byRoute,
+		byAsset:    byAsset,
+		journal:    journal,
+		cursor:     cursor,
+		federation: fed,
+		observed:   make(map[observedKey]observedEvent),
+		logger:     logger,
+	}
+
+	if fed != nil {
+		fed.SetValidator(b.validateProposal)
+	}
+
+	return b, nil
+}
+
+// observe records that this node has independently seen event as the source
+// of a swap it may later be asked to perform or roll back, so
+// validateProposal can confirm a peer's proposal for it before counter-
+// signing. It's called for both legs up front, since which leg actually gets
+// proposed depends on how the swap's perform step goes.
+func (b *Bridge) observe(route string, event Event) {
+	b.observedMu.Lock()
+	defer b.observedMu.Unlock()
+
+	seen := observedEvent{amount: event.Amount(), destination: event.Destination()}
+	b.observed[observedKey{operation: route + ":perform", source: event.Key()}] = seen
+	b.observed[observedKey{operation: route + ":rollback", source: event.Key()}] = seen
+}
+
+// forgetObserved discards event's observed record once its swap reaches a
+// terminal state, so the registry doesn't grow without bound.
+func (b *Bridge) forgetObserved(route string, event Event) {
+	b.observedMu.Lock()
+	defer b.observedMu.Unlock()
+
+	delete(b.observed, observedKey{operation: route + ":perform", source: event.Key()})
+	delete(b.observed, observedKey{operation: route + ":rollback", source: event.Key()})
 }
 
-func New(avalanche *avalanche.Avalanche, tezos *tezos.Tezos, logger *zap.SugaredLogger) *Bridge {
-	return &Bridge{
-		avalanche: avalanche,
-		tezos:     tezos,
-		logger:    logger,
+// validateProposal is this node's federation.Validator: it refuses to
+// counter-sign any proposal that doesn't match an event it has
+// independently observed on the source chain itself, so a compromised peer
+// can't invent a proposal out of thin air and collect rubber-stamp
+// signatures for it.
+func (b *Bridge) validateProposal(p federation.Proposal) bool {
+	b.observedMu.Lock()
+	defer b.observedMu.Unlock()
+
+	seen, ok := b.observed[observedKey{operation: p.Operation, source: p.SourceKey}]
+	if !ok || p.Amount == nil {
+		return false
 	}
+
+	return seen.destination == p.Destination && seen.amount.Cmp(p.Amount) == 0
 }
 
 func (b *Bridge) Run(ctx context.Context) error {
-	avaSub, err := b.avalanche.Subscribe(ctx)
-	if err != nil {
-		return fmt.Errorf("subscribe avalanche: %w", err)
+	atomic := NewAtomic(
+		WithChecker(b.checkOperation),
+		WithJournal(b.journal),
+	)
+	for _, route := range b.routes {
+		perform := b.federate(route.Name+":perform", b.stepFunc(route.DestChain, route.DestAsset, route.Wrap))
+		rollback := b.federate(route.Name+":rollback", b.stepFunc(route.SourceChain, route.SourceAsset, route.Rollback))
+		atomic.Register(route.Name, perform, rollback)
 	}
 
-	tzsSub, err := b.tezos.Subscribe(ctx)
+	subs, err := b.subscribeAll(ctx)
 	if err != nil {
-		return fmt.Errorf("subscribe tezos: %w", err)
+		return err
+	}
+
+	if err := atomic.Recover(ctx); err != nil {
+		return fmt.Errorf("recover journal: %w", err)
 	}
 
 	b.logger.Info("Heimdallr is watching")
-	b.loop(ctx, avaSub, tzsSub)
+	b.loop(ctx, atomic, subs)
 
 	return nil
 }
 
-func (b *Bridge) loop(ctx context.Context, avaSub *avalanche.Subscription, tzsSub *tezos.Subscription) {
-	atomic := NewAtomic(
-		WithChecker(b.checkOperation),
-	)
+type chainSub struct {
+	chain  string
+	events <-chan Event
+	errs   <-chan error
+}
 
-	for {
-		select {
-		// Break loop on interruption
-		case <-ctx.Done():
-			return
+// subscribeAll opens a Subscribe stream on every configured adapter, each
+// backfilled from the earliest cursor of any route sourced from it.
+func (b *Bridge) subscribeAll(ctx context.Context) ([]chainSub, error) {
+	subs := make([]chainSub, 0, len(b.adapters))
+	for name, adapter := range b.adapters {
+		events, errs, err := adapter.Subscribe(ctx, b.backfillFrom(adapter))
+		if err != nil {
+			return nil, fmt.Errorf("subscribe %s: %w", name, err)
+		}
+		subs = append(subs, chainSub{chain: name, events: events, errs: errs})
+	}
+	return subs, nil
+}
+
+// backfillFrom resolves the block/level adapter.Subscribe should backfill
+// from: an explicit override takes priority, otherwise the earliest cursor
+// among every asset this adapter is a source for (so a backfill covers
+// whichever asset has processed the least), or zero (no backfill) if no
+// cursor has ever been advanced.
+func (b *Bridge) backfillFrom(adapter ChainAdapter) uint64 {
+	if override := adapter.StartOverride(); override != 0 {
+		return override
+	}
 
-		// Handle events from chains and call another chain
-		case event := <-avaSub.OnAVAXLocked():
-			swap := atomic.NewOperation(
-				WithName("swap AVAX to WAVAX"),
-				OnPerform(b.mintWAVAX),
-				OnRollback(b.unlockAVAX),
-			)
-			go swap.Run(ctx, event)
-		case event := <-avaSub.OnUSDCLocked():
-			swap := atomic.NewOperation(
-				WithName("swap USDC to WUSDC"),
-				OnPerform(b.mintWUSDC),
-				OnRollback(b.unlockUSDC),
-			)
-			go swap.Run(ctx, event)
-		case event := <-tzsSub.OnWAVAXBurned():
-			swap := atomic.NewOperation(
-				WithName("swap WAVAX to AVAX"),
-				OnPerform(b.unlockAVAX),
-				OnRollback(b.mintWAVAX),
-			)
-			go swap.Run(ctx, event)
-		case event := <-tzsSub.OnWUSDCBurned():
-			swap := atomic.NewOperation(
-				WithName("swap WUSDC to USDC"),
-				OnPerform(b.unlockUSDC),
-				OnRollback(b.mintWUSDC),
-			)
-			go swap.Run(ctx, event)
-
-		// Handle errors occurred during chains subscriptions
-		case err := <-avaSub.Err():
-			b.logger.Errorf("avalanche subscribtion error: %s", err)
-		case err := <-tzsSub.Err():
-			b.logger.Errorf("tezos subscribtion error: %s", err)
+	if b.cursor == nil {
+		return 0
+	}
+
+	var from uint64
+	found := false
+	for asset := range b.byAsset[adapter.Name()] {
+		pos, ok := b.cursor.Get(cursorKey(adapter.Name(), asset))
+		if !ok {
+			continue
+		}
+		pos++ // resume after the last block this cursor actually finished
+		if !found || pos < from {
+			from = pos
+			found = true
 		}
 	}
+
+	return from
 }
 
-func (b *Bridge) mintWAVAX(ctx context.Context, event Event) bool {
-	hash, fee, err := b.tezos.MintWAVAX(ctx, event.Amount())
-	if err != nil {
-		b.logger.Errorf("mint wavax: %s", err)
+// cursorKey is the LastProcessedBlock cursor key for one adapter's asset.
+func cursorKey(chain, asset string) string {
+	return fmt.Sprintf("%s:%s", chain, asset)
+}
 
-		return false
+// loop is a generic fan-in over every subscribed chain's events and errors:
+// it looks up the AssetRoute for whatever asset an event carries and drives
+// it through the Atomic operation that route was registered under.
+func (b *Bridge) loop(ctx context.Context, atomic *Atomic, subs []chainSub) {
+	cases := make([]reflect.SelectCase, 0, 1+2*len(subs))
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+	for _, sub := range subs {
+		cases = append(cases,
+			reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(sub.events)},
+			reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(sub.errs)},
+		)
 	}
 
-	b.logger.With(
-		zap.String("user", event.User()),
-		zap.Int64("amount", event.Amount().Int64()),
-		zap.String("destination", event.Destination()),
-		zap.String("tx_hash", hash),
-		zap.Int64("fee", fee.Int64()),
-	).Info("wavax minted")
+	for {
+		chosen, recv, ok := reflect.Select(cases)
+		if chosen == 0 {
+			return
+		}
+		if !ok {
+			continue
+		}
 
-	hash, fee, err = b.tezos.TransferWAVAX(ctx, event.Destination(), event.Amount())
-	if err != nil {
-		b.logger.Errorf("mint wavax: %s", err)
+		sub := subs[(chosen-1)/2]
+		if (chosen-1)%2 == 0 {
+			b.dispatch(ctx, atomic, sub.chain, recv.Interface().(Event))
+		} else {
+			b.logger.Errorf("%s subscription error: %s", sub.chain, recv.Interface().(error))
+		}
+	}
+}
 
-		return false
+// dispatch looks up the route an event's chain+asset belongs to and runs it
+// as a new swap Operation.
+func (b *Bridge) dispatch(ctx context.Context, atomic *Atomic, chain string, event Event) {
+	route, ok := b.byAsset[chain][event.Asset()]
+	if !ok {
+		b.logger.Errorf("no route registered for %s asset %q", chain, event.Asset())
+		return
 	}
 
-	b.logger.With(
-		zap.String("user", event.User()),
-		zap.Int64("amount", event.Amount().Int64()),
-		zap.String("destination", event.Destination()),
-		zap.String("tx_hash", hash),
-		zap.Int64("fee", fee.Int64()),
-	).Info("wavax transferred")
+	if b.federation != nil {
+		b.observe(route.Name, event)
+	}
 
-	return true
+	go b.newSwap(atomic, route.Name).Run(ctx, event)
 }
 
-func (b *Bridge) mintWUSDC(ctx context.Context, event Event) bool {
-	hash, fee, err := b.tezos.MintWUSDC(ctx, event.Amount())
-	if err != nil {
-		b.logger.Errorf("mint wusdc: %s", err)
+// newSwap builds the Operation for a named swap, reusing the exact
+// perform/rollback StepFuncs registered with atomic so a live swap and one
+// resumed by Atomic.Recover always run the same (possibly federation-wrapped)
+// code.
+func (b *Bridge) newSwap(atomic *Atomic, name string) *Operation {
+	perform, rollback, _ := atomic.Template(name)
+	return atomic.NewOperation(WithName(name), OnPerform(perform), OnRollback(rollback))
+}
 
+// stepFunc builds the StepFunc that credits asset on chain via mode (minting
+// and transferring it, or unlocking it directly), for use as either a
+// route's forward leg or its rollback.
+func (b *Bridge) stepFunc(chain, asset string, mode WrapMode) StepFunc {
+	adapter := b.adapters[chain]
+
+	return func(ctx context.Context, event Event, steps Recorder) bool {
+		switch mode {
+		case WrapMint:
+			return b.mintAndTransfer(ctx, adapter, asset, event, steps)
+		case WrapUnlock:
+			return b.unlock(ctx, adapter, asset, event, steps)
+		default:
+			b.logger.Errorf("route step for %s %s: unknown wrap mode %q", chain, asset, mode)
+			return false
+		}
+	}
+}
+
+func (b *Bridge) mintAndTransfer(ctx context.Context, adapter ChainAdapter, asset string, event Event, steps Recorder) bool {
+	hash, fee, err := b.journaledStep(ctx, adapter, event, steps, "mint", func(ctx context.Context) (string, *big.Int, error) {
+		return adapter.Mint(ctx, asset, event.Amount())
+	})
+	if err != nil {
+		b.logger.Errorf("mint %s: %s", asset, err)
 		return false
 	}
 
@@ -146,12 +328,13 @@ func (b *Bridge) mintWUSDC(ctx context.Context, event Event) bool {
 		zap.String("destination", event.Destination()),
 		zap.String("tx_hash", hash),
 		zap.Int64("fee", fee.Int64()),
-	).Info("wusdc minted")
+	).Infof("%s minted", asset)
 
-	hash, fee, err = b.tezos.TransferWUSDC(ctx, event.Destination(), event.Amount())
+	hash, fee, err = b.journaledStep(ctx, adapter, event, steps, "transfer", func(ctx context.Context) (string, *big.Int, error) {
+		return adapter.Transfer(ctx, asset, event.Destination(), event.Amount())
+	})
 	if err != nil {
-		b.logger.Errorf("transfer wusdc: %s", err)
-
+		b.logger.Errorf("transfer %s: %s", asset, err)
 		return false
 	}
 
@@ -161,16 +344,17 @@ func (b *Bridge) mintWUSDC(ctx context.Context, event Event) bool {
 		zap.String("destination", event.Destination()),
 		zap.String("tx_hash", hash),
 		zap.Int64("fee", fee.Int64()),
-	).Info("wusdc transferred")
+	).Infof("%s transferred", asset)
 
 	return true
 }
 
-func (b *Bridge) unlockAVAX(ctx context.Context, event Event) bool {
-	hash, fee, err := b.avalanche.UnlockAVAX(ctx, event.Destination(), event.Amount())
+func (b *Bridge) unlock(ctx context.Context, adapter ChainAdapter, asset string, event Event, steps Recorder) bool {
+	hash, fee, err := b.journaledStep(ctx, adapter, event, steps, "unlock", func(ctx context.Context) (string, *big.Int, error) {
+		return adapter.Unlock(ctx, asset, event.Destination(), event.Amount())
+	})
 	if err != nil {
-		b.logger.Errorf("unlock avax: %s", err)
-
+		b.logger.Errorf("unlock %s: %s", asset, err)
 		return false
 	}
 
@@ -180,29 +364,45 @@ func (b *Bridge) unlockAVAX(ctx context.Context, event Event) bool {
 		zap.String("destination", event.Destination()),
 		zap.String("tx_hash", hash),
 		zap.Int64("fee", fee.Int64()),
-	).Info("avax unlocked")
+	).Infof("%s unlocked", asset)
 
 	return true
 }
 
-func (b *Bridge) unlockUSDC(ctx context.Context, event Event) bool {
-	hash, fee, err := b.avalanche.UnlockUSDC(ctx, event.Destination(), event.Amount())
-	if err != nil {
-		b.logger.Errorf("unlock usdc: %s", err)
+// journaledStep runs submit unless a prior attempt at this step already
+// recorded a destination tx hash in the journal. If the journal doesn't know
+// about it, it still asks adapter whether a call tagged with this step's
+// idempotency key has already landed on the destination chain before
+// resubmitting -- closing the crash window between submit() broadcasting a
+// call and Record fsyncing its hash, which the journal alone can't see
+// across a restart.
+func (b *Bridge) journaledStep(ctx context.Context, adapter ChainAdapter, event Event, steps Recorder, id string, submit func(context.Context) (string, *big.Int, error)) (string, *big.Int, error) {
+	if hash, ok := steps.Done(id); ok {
+		return hash, big.NewInt(0), nil
+	}
 
-		return false
+	key := event.Key() + ":" + id
+	if hash, ok, err := adapter.Landed(ctx, key); err != nil {
+		b.logger.Errorf("check %q landed on destination chain: %s", id, err)
+	} else if ok {
+		if err := steps.Record(id, hash, big.NewInt(0)); err != nil {
+			b.logger.Errorf("journal step %q recovered from destination chain: %s", id, err)
+		}
+		return hash, big.NewInt(0), nil
 	}
 
-	b.logger.With(
-		zap.String("user", event.User()),
-		zap.Int64("amount", event.Amount().Int64()),
-		zap.String("destination", event.Destination()),
-		zap.String("tx_hash", hash),
-		zap.Int64("fee", fee.Int64()),
-	).Info("usdc unlocked")
+	hash, fee, err := submit(idempotency.WithKey(ctx, key))
+	if err != nil {
+		return "", nil, err
+	}
 
-	return true
+	if err := steps.Record(id, hash, fee); err != nil {
+		b.logger.Errorf("journal step %q: %s", id, err)
+	}
+
+	return hash, fee, nil
 }
+
 func (b *Bridge) checkOperation(op Checker, event Event) {
 	select {
 	case <-op.Complete():
@@ -211,12 +411,16 @@ func (b *Bridge) checkOperation(op Checker, event Event) {
 			zap.String("to", event.Destination()),
 			zap.Int64("amount", event.Amount().Int64()),
 		).Info("swap complete")
+		b.advanceCursor(op.Name(), event)
+		b.forgetObserved(op.Name(), event)
 	case <-op.Rollback():
 		b.logger.With(
 			zap.String("from", event.User()),
 			zap.String("to", event.Destination()),
 			zap.Int64("amount", event.Amount().Int64()),
 		).Info("swap rolled back")
+		b.advanceCursor(op.Name(), event)
+		b.forgetObserved(op.Name(), event)
 	// Should not happen ever, because operation failing leads to coins lost.
 	// Only contract owner will be able to unlock or mint lost coins.
 	case err := <-op.Fail():
@@ -228,3 +432,23 @@ func (b *Bridge) checkOperation(op Checker, event Event) {
 		).Debug("swap failed")
 	}
 }
+
+// advanceCursor moves the LastProcessedBlock cursor for the event's source
+// chain+asset past its position, now that its Operation has reached a
+// terminal state and will never be replayed from a historical backfill
+// again.
+func (b *Bridge) advanceCursor(operation string, event Event) {
+	if b.cursor == nil {
+		return
+	}
+
+	route, ok := b.routes[operation]
+	if !ok {
+		return
+	}
+
+	key := cursorKey(route.SourceChain, route.SourceAsset)
+	if err := b.cursor.Advance(key, event.Position()); err != nil {
+		b.logger.Errorf("advance cursor %q: %s", key, err)
+	}
+}
@@ -0,0 +1,74 @@
+// Package cursor tracks, per chain and asset, the last block/level
+// Heimdallr has fully processed, so a restart after downtime can backfill
+// whatever was missed instead of only watching the chain tip.
+package cursor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Store persists a set of named cursors to a single JSON file. Keys are
+// chain-qualified asset symbols, e.g. "avalanche:AVAX" or
+// "tezos:WAVAX".
+type Store struct {
+	mu   sync.Mutex
+	path string
+	data map[string]uint64
+}
+
+// Open loads the cursor file at path, or starts with an empty set if it
+// doesn't exist yet.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, data: make(map[string]uint64)}
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read cursor file %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(raw, &s.data); err != nil {
+		return nil, fmt.Errorf("unmarshal cursor file %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// Get returns the last position recorded for key, if any.
+func (s *Store) Get(key string) (uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pos, ok := s.data[key]
+	return pos, ok
+}
+
+// Advance records position for key, but only if it's past the current
+// cursor, so an out-of-order or duplicate call can never move a cursor
+// backwards.
+func (s *Store) Advance(key string, position uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.data[key]; ok && position <= current {
+		return nil
+	}
+	s.data[key] = position
+
+	raw, err := json.Marshal(s.data)
+	if err != nil {
+		return fmt.Errorf("marshal cursors: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("write cursor file: %w", err)
+	}
+
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,65 @@
+package bridge
+
+import (
+	"context"
+	"math/big"
+
+	"heimdallr/internal/ethereum"
+)
+
+// ethereumAdapter makes *ethereum.Ethereum satisfy ChainAdapter. See
+// avalancheAdapter for why this wrapping lives here instead of in package
+// ethereum.
+type ethereumAdapter struct {
+	*ethereum.Ethereum
+}
+
+// NewEthereumAdapter wraps eth so it can be passed to Bridge.New.
+func NewEthereumAdapter(eth *ethereum.Ethereum) ChainAdapter {
+	return ethereumAdapter{eth}
+}
+
+func (a ethereumAdapter) StartOverride() uint64 {
+	return a.Ethereum.StartBlockOverride()
+}
+
+func (a ethereumAdapter) Subscribe(ctx context.Context, from uint64) (<-chan Event, <-chan error, error) {
+	sub, err := a.Ethereum.Subscribe(ctx, from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-sub.Locked():
+				if !ok {
+					return
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, sub.Err(), nil
+}
+
+func (a ethereumAdapter) Mint(ctx context.Context, asset string, amount *big.Int) (string, *big.Int, error) {
+	return a.Ethereum.Mint(ctx, asset, amount)
+}
+
+func (a ethereumAdapter) Transfer(ctx context.Context, asset, destination string, amount *big.Int) (string, *big.Int, error) {
+	return a.Ethereum.Transfer(ctx, asset, destination, amount)
+}
+
+func (a ethereumAdapter) Unlock(ctx context.Context, asset, destination string, amount *big.Int) (string, *big.Int, error) {
+	return a.Ethereum.Unlock(ctx, asset, destination, amount)
+}
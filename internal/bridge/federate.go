@@ -0,0 +1,57 @@
+package bridge
+
+import (
+	"context"
+
+	"heimdallr/internal/bridge/federation"
+)
+
+// federate wraps a StepFunc so it only executes once a federation.Coordinator
+// has gathered M-of-N approval for it, and only on the node deterministically
+// elected to lead that proposal. label identifies the route and leg being
+// proposed (e.g. "swap AVAX to WAVAX:perform"), the same name validateProposal
+// looks observed events up under.
+//
+// If a leader fails to gather approval within its ProposalTimeout, the next
+// leader in the rotation gets a fresh attempt, up to once per federation
+// peer. A node that isn't the elected leader for the attempt that finally
+// succeeds has nothing left to do for this step: the leader's chain call
+// covers it.
+func (b *Bridge) federate(label string, fn StepFunc) StepFunc {
+	if b.federation == nil {
+		return fn
+	}
+
+	return func(ctx context.Context, event Event, steps Recorder) bool {
+		proposal := federation.Proposal{
+			Operation:   label,
+			SourceKey:   event.Key(),
+			Amount:      event.Amount(),
+			Destination: event.Destination(),
+		}
+
+		for attempt := 0; attempt < b.federation.Peers(); attempt++ {
+			bundle, err := b.federation.Approve(ctx, proposal)
+			if err != nil {
+				b.logger.Errorf("federation approve %s (attempt %d): %s", label, attempt, err)
+				continue
+			}
+
+			if !b.federation.Leader(proposal, attempt) {
+				ok, err := b.federation.Await(ctx, proposal)
+				if err != nil {
+					b.logger.Errorf("await leader outcome for %s (attempt %d): %s", label, attempt, err)
+					continue
+				}
+				return ok
+			}
+
+			ok := fn(federation.WithBundle(ctx, bundle), event, steps)
+			b.federation.ReportOutcome(ctx, proposal, ok)
+			return ok
+		}
+
+		b.logger.Errorf("federation approve %s: exhausted leader rotation", label)
+		return false
+	}
+}
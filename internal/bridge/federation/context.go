@@ -0,0 +1,17 @@
+package federation
+
+import "context"
+
+type bundleKey struct{}
+
+// WithBundle attaches an approved Bundle to ctx, so the leader's chain-call
+// code can read it back out and attach it to the transaction it submits.
+func WithBundle(ctx context.Context, bundle Bundle) context.Context {
+	return context.WithValue(ctx, bundleKey{}, bundle)
+}
+
+// BundleFromContext retrieves a Bundle attached by WithBundle, if any.
+func BundleFromContext(ctx context.Context) (Bundle, bool) {
+	bundle, ok := ctx.Value(bundleKey{}).(Bundle)
+	return bundle, ok
+}
@@ -0,0 +1,111 @@
+package federation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Coordinator drives one node's side of getting a Proposal to M-of-N
+// approval, and decides whether this node is the one that should actually
+// execute it.
+type Coordinator struct {
+	cfg   Config
+	mesh  *Mesh
+	peers []PeerID // cfg.Peers' IDs, sorted, so every node rotates leaders identically
+}
+
+func NewCoordinator(cfg Config, mesh *Mesh) (*Coordinator, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("federation config: %w", err)
+	}
+
+	peers := make([]PeerID, len(cfg.Peers))
+	for i, p := range cfg.Peers {
+		peers[i] = p.ID
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+
+	return &Coordinator{cfg: cfg, mesh: mesh, peers: peers}, nil
+}
+
+// Peers is how many validators participate in this federation, and so the
+// number of distinct leaders Leader can rotate through for one proposal.
+func (c *Coordinator) Peers() int { return len(c.peers) }
+
+// SetValidator installs the check a peer runs against an incoming Proposal
+// before counter-signing it, so a compromised node can't invent a proposal
+// and collect rubber-stamp signatures for it.
+func (c *Coordinator) SetValidator(v Validator) {
+	c.mesh.SetValidator(v)
+}
+
+// Await blocks until the elected leader for proposal reports how its
+// execution went (see ReportOutcome), or ctx/ProposalTimeout expires first.
+// A non-leader calls this instead of assuming the leader's approved
+// proposal actually landed.
+func (c *Coordinator) Await(ctx context.Context, proposal Proposal) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeoutOrDefault(c.cfg.ProposalTimeout))
+	defer cancel()
+
+	return c.mesh.await(ctx, proposal)
+}
+
+// ReportOutcome tells every other peer whether proposal's chain call
+// succeeded, so a non-leader's Await can report the real result instead of
+// assuming success.
+func (c *Coordinator) ReportOutcome(ctx context.Context, proposal Proposal, success bool) {
+	c.mesh.reportOutcome(ctx, proposal, success)
+}
+
+// Leader reports whether this node is the one that should execute proposal's
+// chain call on the given attempt (0 for the first try, incrementing each
+// time the previous leader fails to gather Threshold approvals within
+// ProposalTimeout).
+func (c *Coordinator) Leader(proposal Proposal, attempt int) bool {
+	return leaderAt(c.peers, proposal, attempt) == c.cfg.Self
+}
+
+// Approve gathers Threshold valid signatures over proposal, starting with
+// this node's own, and returns the resulting Bundle. It asks every peer
+// concurrently and returns as soon as enough have answered; it does not wait
+// for stragglers.
+func (c *Coordinator) Approve(ctx context.Context, proposal Proposal) (Bundle, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeoutOrDefault(c.cfg.ProposalTimeout))
+	defer cancel()
+
+	bundle := Bundle{Proposal: proposal, Approvals: []Approval{Sign(c.cfg, proposal)}}
+	if len(bundle.Approvals) >= c.cfg.Threshold {
+		return bundle, nil
+	}
+
+	approvals := make(chan Approval, len(c.cfg.Peers))
+	for _, peer := range c.cfg.Peers {
+		if peer.ID == c.cfg.Self {
+			continue
+		}
+
+		go func(peer Peer) {
+			approval, err := c.mesh.RequestApproval(ctx, peer, proposal)
+			if err != nil || !Verify(peer, proposal, approval) {
+				return
+			}
+
+			select {
+			case approvals <- approval:
+			case <-ctx.Done():
+			}
+		}(peer)
+	}
+
+	for len(bundle.Approvals) < c.cfg.Threshold {
+		select {
+		case approval := <-approvals:
+			bundle.Approvals = append(bundle.Approvals, approval)
+		case <-ctx.Done():
+			return Bundle{}, fmt.Errorf("gather %d/%d approvals for proposal: %w", len(bundle.Approvals), c.cfg.Threshold, ctx.Err())
+		}
+	}
+
+	return bundle, nil
+}
@@ -0,0 +1,22 @@
+package federation
+
+import "encoding/binary"
+
+// leaderAt deterministically elects which peer executes proposal's chain
+// call on the given attempt. Every node computes the same answer from the
+// proposal hash alone, with no coordination round needed. attempt rotates
+// the choice, so a leader that times out without gathering enough
+// approvals is replaced by the next one in a fixed, reproducible order.
+func leaderAt(peers []PeerID, proposal Proposal, attempt int) PeerID {
+	hash := proposal.Hash()
+	index := binary.BigEndian.Uint64(hash[:8])
+
+	// peers must be in a stable order across all nodes (callers sort by ID)
+	// for this rotation to agree everywhere.
+	offset := (int(index) + attempt) % len(peers)
+	if offset < 0 {
+		offset += len(peers)
+	}
+
+	return peers[offset]
+}
@@ -0,0 +1,211 @@
+package federation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi"
+)
+
+// ApprovalPath is where Mesh exposes its counter-signing endpoint.
+const ApprovalPath = "/federation/approve"
+
+// OutcomePath is where Mesh exposes its leader-outcome endpoint: the node
+// that actually executed a proposal reports how it went, so peers that
+// merely counter-signed it aren't left guessing. See Coordinator.Await.
+const OutcomePath = "/federation/outcome"
+
+// Validator reports whether proposal corresponds to a real,
+// independently-observed source-chain event, so handleApprove never
+// counter-signs something this node can't itself verify. A Mesh with no
+// Validator installed (see SetValidator) refuses every proposal.
+type Validator func(Proposal) bool
+
+// Mesh is this node's side of the peer-to-peer approval exchange: an HTTP
+// server that counter-signs proposals from fellow validators and relays
+// execution outcomes, and a client for asking them to do the same for ours.
+type Mesh struct {
+	cfg      Config
+	client   *http.Client
+	validate Validator
+
+	mu      sync.Mutex
+	waiters map[[32]byte]chan bool
+}
+
+func NewMesh(cfg Config) *Mesh {
+	return &Mesh{
+		cfg:     cfg,
+		client:  &http.Client{Timeout: timeoutOrDefault(cfg.ProposalTimeout)},
+		waiters: make(map[[32]byte]chan bool),
+	}
+}
+
+// SetValidator installs the check handleApprove runs against an incoming
+// peer's Proposal before counter-signing it. It must be called before
+// Handler starts serving requests.
+func (m *Mesh) SetValidator(v Validator) {
+	m.validate = v
+}
+
+// Handler serves ApprovalPath and OutcomePath: ApprovalPath signs a Proposal
+// this node has independently verified against its own observed chain state
+// (see SetValidator) and returns this node's Approval; OutcomePath accepts
+// the executing leader's report of how a proposal it already approved
+// turned out, waking up Await.
+func (m *Mesh) Handler() http.Handler {
+	r := chi.NewRouter()
+	r.Post(ApprovalPath, m.handleApprove)
+	r.Post(OutcomePath, m.handleOutcome)
+	return r
+}
+
+func (m *Mesh) handleApprove(w http.ResponseWriter, r *http.Request) {
+	var proposal Proposal
+	if err := json.NewDecoder(r.Body).Decode(&proposal); err != nil {
+		http.Error(w, fmt.Sprintf("decode proposal: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if m.validate == nil || !m.validate(proposal) {
+		http.Error(w, "proposal does not match an independently-observed source-chain event", http.StatusForbidden)
+		return
+	}
+
+	approval := Sign(m.cfg, proposal)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(approval)
+}
+
+// outcome is what the executing leader reports to its peers once it knows
+// whether its chain call succeeded.
+type outcome struct {
+	Proposal Proposal
+	Success  bool
+}
+
+func (m *Mesh) handleOutcome(w http.ResponseWriter, r *http.Request) {
+	var o outcome
+	if err := json.NewDecoder(r.Body).Decode(&o); err != nil {
+		http.Error(w, fmt.Sprintf("decode outcome: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	m.deliver(o.Proposal.Hash(), o.Success)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *Mesh) deliver(hash [32]byte, success bool) {
+	m.mu.Lock()
+	waiter, ok := m.waiters[hash]
+	m.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	select {
+	case waiter <- success:
+	default:
+	}
+}
+
+// await blocks until an outcome for proposal is delivered by handleOutcome,
+// or ctx is done, whichever comes first.
+func (m *Mesh) await(ctx context.Context, proposal Proposal) (bool, error) {
+	hash := proposal.Hash()
+
+	waiter := make(chan bool, 1)
+	m.mu.Lock()
+	m.waiters[hash] = waiter
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		delete(m.waiters, hash)
+		m.mu.Unlock()
+	}()
+
+	select {
+	case success := <-waiter:
+		return success, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// reportOutcome tells every peer but self how proposal's execution turned
+// out. It is fire-and-forget: a peer that misses the report (or was never
+// reachable) simply times out its own Await and re-enters the leader
+// rotation, so this never blocks the caller on a slow or dead peer.
+func (m *Mesh) reportOutcome(ctx context.Context, proposal Proposal, success bool) {
+	body, err := json.Marshal(outcome{Proposal: proposal, Success: success})
+	if err != nil {
+		return
+	}
+
+	for _, peer := range m.cfg.Peers {
+		if peer.ID == m.cfg.Self {
+			continue
+		}
+
+		go func(peer Peer) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, peer.Address+OutcomePath, bytes.NewReader(body))
+			if err != nil {
+				return
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := m.client.Do(req)
+			if err != nil {
+				return
+			}
+			resp.Body.Close()
+		}(peer)
+	}
+}
+
+// RequestApproval asks peer to counter-sign proposal, over HTTP.
+func (m *Mesh) RequestApproval(ctx context.Context, peer Peer, proposal Proposal) (Approval, error) {
+	body, err := json.Marshal(proposal)
+	if err != nil {
+		return Approval{}, fmt.Errorf("marshal proposal: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, peer.Address+ApprovalPath, bytes.NewReader(body))
+	if err != nil {
+		return Approval{}, fmt.Errorf("build request to %s: %w", peer.ID, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Approval{}, fmt.Errorf("request approval from %s: %w", peer.ID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Approval{}, fmt.Errorf("peer %s refused proposal: status %d", peer.ID, resp.StatusCode)
+	}
+
+	var approval Approval
+	if err := json.NewDecoder(resp.Body).Decode(&approval); err != nil {
+		return Approval{}, fmt.Errorf("decode approval from %s: %w", peer.ID, err)
+	}
+
+	return approval, nil
+}
+
+// timeoutOrDefault guards against a zero-value ProposalTimeout turning into
+// an indefinitely-blocking http.Client.
+func timeoutOrDefault(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 30 * time.Second
+	}
+	return d
+}
@@ -0,0 +1,59 @@
+// Package federation turns a single Heimdallr instance's mint/unlock calls
+// into M-of-N threshold-approved actions, gossiped to a configured set of
+// peer validators before any one of them touches a chain. This bounds the
+// blast radius of a single compromised node, which otherwise holds both
+// chains' signing keys and can unilaterally drain the bridge.
+package federation
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"time"
+)
+
+// PeerID identifies a validator in the federation. It is the hex-encoded
+// Ed25519 public key, so it doubles as the key used to verify that peer's
+// approvals.
+type PeerID string
+
+// Peer is one member of the federation: where to reach it, and the key its
+// approvals must verify against.
+type Peer struct {
+	ID        PeerID
+	Address   string // base URL of its federation HTTP mesh endpoint, e.g. "http://host:port"
+	PublicKey ed25519.PublicKey
+}
+
+// Config describes this node's place in the federation.
+type Config struct {
+	Self       PeerID
+	PrivateKey ed25519.PrivateKey
+	Peers      []Peer // the full validator set, including Self
+
+	// Threshold is how many signatures (including this node's own, if it
+	// signs) a proposal needs before it's approved.
+	Threshold int
+
+	// ProposalTimeout bounds how long the elected leader waits for
+	// Threshold signatures before re-electing a fallback leader.
+	ProposalTimeout time.Duration
+}
+
+func (c Config) validate() error {
+	if c.Threshold < 1 || c.Threshold > len(c.Peers) {
+		return fmt.Errorf("threshold %d invalid for %d peers", c.Threshold, len(c.Peers))
+	}
+
+	found := false
+	for _, p := range c.Peers {
+		if p.ID == c.Self {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("self %q is not a member of the configured peer set", c.Self)
+	}
+
+	return nil
+}
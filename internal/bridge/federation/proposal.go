@@ -0,0 +1,64 @@
+package federation
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// Proposal is what the federation votes on: one leg of a swap (its forward
+// leg or its rollback), exactly as it will be submitted to the chain. Signing the same
+// Proposal twice always produces the same hash, so peers can't be tricked
+// into co-signing a swap that differs from what actually gets executed.
+type Proposal struct {
+	Operation   string
+	SourceKey   string // the source event's idempotency key, see bridge.Event.Key
+	Amount      *big.Int
+	Destination string
+	Nonce       uint64
+}
+
+// Hash is the canonical, signable digest of the proposal.
+func (p Proposal) Hash() [32]byte {
+	amount := "0"
+	if p.Amount != nil {
+		amount = p.Amount.String()
+	}
+
+	payload := fmt.Sprintf("%s|%s|%s|%s|%d", p.Operation, p.SourceKey, amount, p.Destination, p.Nonce)
+	return sha256.Sum256([]byte(payload))
+}
+
+// Approval is one peer's Ed25519 signature over a Proposal's hash.
+type Approval struct {
+	Peer      PeerID
+	Signature []byte
+}
+
+// Verify reports whether approval is a valid signature from peer over
+// proposal, using peer's known public key.
+func Verify(peer Peer, proposal Proposal, approval Approval) bool {
+	if approval.Peer != peer.ID {
+		return false
+	}
+	hash := proposal.Hash()
+	return ed25519.Verify(peer.PublicKey, hash[:], approval.Signature)
+}
+
+// Sign produces this node's own Approval for proposal.
+func Sign(cfg Config, proposal Proposal) Approval {
+	hash := proposal.Hash()
+	return Approval{
+		Peer:      cfg.Self,
+		Signature: ed25519.Sign(cfg.PrivateKey, hash[:]),
+	}
+}
+
+// Bundle is a Proposal together with enough Approvals to meet the
+// federation's threshold. It is attached to the destination-chain
+// transaction's metadata so the approval can be verified on-chain later.
+type Bundle struct {
+	Proposal  Proposal
+	Approvals []Approval
+}
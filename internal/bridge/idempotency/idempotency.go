@@ -0,0 +1,21 @@
+// Package idempotency lets Bridge tag an outgoing chain call with the same
+// stable key its journal records the corresponding step under, so a
+// ChainAdapter's Landed can recognize that exact call again after a crash
+// between broadcasting it and the journal fsyncing its hash.
+package idempotency
+
+import "context"
+
+type keyCtx struct{}
+
+// WithKey attaches key to ctx, for a ChainAdapter's Mint/Transfer/Unlock
+// call to read back and embed in whatever it submits.
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, keyCtx{}, key)
+}
+
+// FromContext retrieves a key attached by WithKey, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(keyCtx{}).(string)
+	return key, ok
+}
@@ -0,0 +1,291 @@
+// Package journal gives bridge.Atomic a durable, replayable record of every
+// swap it has in flight, so a crash between steps (e.g. after minting a
+// wrapped asset but before transferring it out) doesn't strand funds.
+package journal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// State is a point in an Operation's lifecycle, as seen from the journal.
+type State string
+
+const (
+	StateCreated     State = "created"
+	StatePerformed   State = "performed"
+	StateTransferred State = "transferred"
+	StateComplete    State = "complete"
+	StateRollback    State = "rollback"
+	StateRolledBack  State = "rolled_back"
+	StateFailed      State = "failed"
+)
+
+// Terminal reports whether a record in this state will never transition
+// again, and is therefore eligible for compaction once it ages out.
+func (s State) Terminal() bool {
+	switch s {
+	case StateComplete, StateRolledBack, StateFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Key identifies the swap a record belongs to. It is built from the source
+// chain event so replays are idempotent: the same lock/burn always maps to
+// the same journal entries.
+type Key string
+
+// Record is one append-only entry in the journal: a state transition for a
+// swap, a snapshot of the source event (so Atomic.Recover can reconstruct it
+// without re-reading the source chain), and whatever destination tx hashes
+// have been recorded for its sub-steps so far.
+type Record struct {
+	Key         Key             `json:"key"`
+	Operation   string          `json:"operation"`
+	State       State           `json:"state"`
+	User        string          `json:"user"`
+	Amount      string          `json:"amount"`
+	Destination string          `json:"destination"`
+	Asset       string          `json:"asset"`
+	Position    uint64          `json:"position"`
+	Steps       map[string]Step `json:"steps,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	Time        time.Time       `json:"time"`
+}
+
+// Step records that a named sub-step of an Operation (e.g. "mint",
+// "transfer") produced a destination tx hash, so a replay can check whether
+// it already happened instead of re-submitting it.
+type Step struct {
+	TxHash string `json:"tx_hash"`
+	Fee    string `json:"fee,omitempty"`
+}
+
+// Journal is an append-only, length-prefixed-JSON log of Operation state
+// transitions, fsync'd on every write so a process crash can never lose the
+// last committed step.
+type Journal struct {
+	mu   sync.Mutex
+	file *os.File
+	path string
+}
+
+// Open creates the journal file if it doesn't exist and appends to it
+// otherwise.
+func Open(path string) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open journal %s: %w", path, err)
+	}
+
+	return &Journal{file: file, path: path}, nil
+}
+
+// Close releases the underlying file handle.
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
+
+// Append writes rec as a new length-prefixed record and fsyncs before
+// returning, so the caller can rely on it surviving a crash.
+func (j *Journal) Append(rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal record: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+
+	if _, err := j.file.Write(length[:]); err != nil {
+		return fmt.Errorf("write record length: %w", err)
+	}
+	if _, err := j.file.Write(data); err != nil {
+		return fmt.Errorf("write record: %w", err)
+	}
+
+	return j.file.Sync()
+}
+
+// Pending replays the journal and returns the latest record for every key
+// that has not yet reached a terminal state. These are the operations
+// Atomic.Recover must resume.
+func (j *Journal) Pending() ([]Record, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seek journal: %w", err)
+	}
+
+	latest := make(map[Key]Record)
+	reader := bufio.NewReader(j.file)
+
+	for {
+		rec, err := readRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read journal: %w", err)
+		}
+		latest[rec.Key] = rec
+	}
+
+	if _, err := j.file.Seek(0, io.SeekEnd); err != nil {
+		return nil, fmt.Errorf("seek journal: %w", err)
+	}
+
+	pending := make([]Record, 0, len(latest))
+	for _, rec := range latest {
+		if !rec.State.Terminal() {
+			pending = append(pending, rec)
+		}
+	}
+
+	return pending, nil
+}
+
+// Latest returns the most recent record for key, if the journal has ever
+// seen it. Callers use this to recognize an event they've already finished
+// with, e.g. one redelivered by a historical backfill that overlaps
+// already-processed blocks.
+func (j *Journal) Latest(key Key) (Record, bool, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.file.Seek(0, io.SeekStart); err != nil {
+		return Record{}, false, fmt.Errorf("seek journal: %w", err)
+	}
+
+	var latest Record
+	found := false
+	reader := bufio.NewReader(j.file)
+
+	for {
+		rec, err := readRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Record{}, false, fmt.Errorf("read journal: %w", err)
+		}
+		if rec.Key == key {
+			latest = rec
+			found = true
+		}
+	}
+
+	if _, err := j.file.Seek(0, io.SeekEnd); err != nil {
+		return Record{}, false, fmt.Errorf("seek journal: %w", err)
+	}
+
+	return latest, found, nil
+}
+
+// Compact rewrites the journal keeping only the latest record per key,
+// dropping terminal records older than retention entirely.
+func (j *Journal) Compact(retention time.Duration) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("seek journal: %w", err)
+	}
+
+	latest := make(map[Key]Record)
+	reader := bufio.NewReader(j.file)
+	for {
+		rec, err := readRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read journal: %w", err)
+		}
+		latest[rec.Key] = rec
+	}
+
+	cutoff := time.Now().Add(-retention)
+	tmpPath := j.path + ".compact"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("create compacted journal: %w", err)
+	}
+
+	for _, rec := range latest {
+		if rec.State.Terminal() && rec.Time.Before(cutoff) {
+			continue
+		}
+
+		data, err := json.Marshal(rec)
+		if err != nil {
+			tmp.Close()
+			return fmt.Errorf("marshal record: %w", err)
+		}
+
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+		if _, err := tmp.Write(length[:]); err != nil {
+			tmp.Close()
+			return fmt.Errorf("write record length: %w", err)
+		}
+		if _, err := tmp.Write(data); err != nil {
+			tmp.Close()
+			return fmt.Errorf("write record: %w", err)
+		}
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync compacted journal: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close compacted journal: %w", err)
+	}
+
+	if err := j.file.Close(); err != nil {
+		return fmt.Errorf("close journal: %w", err)
+	}
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return fmt.Errorf("replace journal: %w", err)
+	}
+
+	file, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("reopen journal: %w", err)
+	}
+	j.file = file
+
+	return nil
+}
+
+func readRecord(r *bufio.Reader) (Record, error) {
+	var length [4]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return Record{}, err
+	}
+
+	data := make([]byte, binary.BigEndian.Uint32(length[:]))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return Record{}, fmt.Errorf("read record body: %w", err)
+	}
+
+	var rec Record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return Record{}, fmt.Errorf("unmarshal record: %w", err)
+	}
+
+	return rec, nil
+}
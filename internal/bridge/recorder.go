@@ -0,0 +1,118 @@
+package bridge
+
+import (
+	"math/big"
+	"time"
+
+	"heimdallr/internal/bridge/journal"
+)
+
+// operationRecorder is the Recorder bound to one Operation's key. It keeps
+// the latest step hashes in memory and, when a journal is configured,
+// appends every mutation to it so the operation can be replayed after a
+// crash.
+type operationRecorder struct {
+	key         journal.Key
+	operation   string
+	user        string
+	amount      string
+	destination string
+	asset       string
+	position    uint64
+
+	j     *journal.Journal
+	steps map[string]journal.Step
+}
+
+func (a *Atomic) recorderFor(event Event, operation string) *operationRecorder {
+	return &operationRecorder{
+		key:         journal.Key(event.Key()),
+		operation:   operation,
+		user:        event.User(),
+		amount:      event.Amount().String(),
+		destination: event.Destination(),
+		asset:       event.Asset(),
+		position:    event.Position(),
+		j:           a.journal,
+		steps:       map[string]journal.Step{},
+	}
+}
+
+func (r *operationRecorder) Done(id string) (string, bool) {
+	step, ok := r.steps[id]
+	if !ok {
+		return "", false
+	}
+	return step.TxHash, true
+}
+
+func (r *operationRecorder) Record(id, hash string, fee *big.Int) error {
+	step := journal.Step{TxHash: hash}
+	if fee != nil {
+		step.Fee = fee.String()
+	}
+	r.steps[id] = step
+
+	return r.append(stateForStep(id), "")
+}
+
+// stateForStep maps a journaled sub-step to the lifecycle state it moves the
+// swap into: "mint"/"unlock" land the asset, "transfer" hands it to the
+// user.
+func stateForStep(id string) journal.State {
+	if id == "transfer" {
+		return journal.StateTransferred
+	}
+	return journal.StatePerformed
+}
+
+func (r *operationRecorder) append(state journal.State, errMsg string) error {
+	if r.j == nil {
+		return nil
+	}
+
+	return r.j.Append(journal.Record{
+		Key:         r.key,
+		Operation:   r.operation,
+		State:       state,
+		User:        r.user,
+		Amount:      r.amount,
+		Destination: r.destination,
+		Asset:       r.asset,
+		Position:    r.position,
+		Steps:       r.steps,
+		Error:       errMsg,
+		Time:        time.Now(),
+	})
+}
+
+// replayEvent reconstructs just enough of a source-chain event from a
+// journal record to resume an Operation. It satisfies Event so Atomic.Recover
+// can hand it straight to the registered perform/rollback funcs.
+type replayEvent struct {
+	user        string
+	amount      *big.Int
+	destination string
+	asset       string
+	key         string
+	position    uint64
+}
+
+func (e replayEvent) User() string        { return e.user }
+func (e replayEvent) Amount() *big.Int    { return e.amount }
+func (e replayEvent) Destination() string { return e.destination }
+func (e replayEvent) Asset() string       { return e.asset }
+func (e replayEvent) Key() string         { return e.key }
+func (e replayEvent) Position() uint64    { return e.position }
+
+func eventFromRecord(rec journal.Record) Event {
+	amount, _ := new(big.Int).SetString(rec.Amount, 10)
+	return replayEvent{
+		user:        rec.User,
+		amount:      amount,
+		destination: rec.Destination,
+		asset:       rec.Asset,
+		key:         string(rec.Key),
+		position:    rec.Position,
+	}
+}
@@ -0,0 +1,64 @@
+package bridge
+
+import (
+	"context"
+	"math/big"
+
+	"heimdallr/internal/tezos"
+)
+
+// tezosAdapter makes *tezos.Tezos satisfy ChainAdapter. See avalancheAdapter
+// for why this wrapping lives here instead of in package tezos.
+type tezosAdapter struct {
+	*tezos.Tezos
+}
+
+// NewTezosAdapter wraps tzs so it can be passed to Bridge.New.
+func NewTezosAdapter(tzs *tezos.Tezos) ChainAdapter {
+	return tezosAdapter{tzs}
+}
+
+func (t tezosAdapter) StartOverride() uint64 {
+	return uint64(t.Tezos.StartLevelOverride())
+}
+
+func (t tezosAdapter) Subscribe(ctx context.Context, from uint64) (<-chan Event, <-chan error, error) {
+	sub, err := t.Tezos.Subscribe(ctx, int64(from))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-sub.Burned():
+				if !ok {
+					return
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, sub.Err(), nil
+}
+
+func (t tezosAdapter) Mint(ctx context.Context, asset string, amount *big.Int) (string, *big.Int, error) {
+	return t.Tezos.Mint(ctx, asset, amount)
+}
+
+func (t tezosAdapter) Transfer(ctx context.Context, asset, destination string, amount *big.Int) (string, *big.Int, error) {
+	return t.Tezos.Transfer(ctx, asset, destination, amount)
+}
+
+func (t tezosAdapter) Unlock(ctx context.Context, asset, destination string, amount *big.Int) (string, *big.Int, error) {
+	return t.Tezos.Unlock(ctx, asset, destination, amount)
+}
@@ -0,0 +1,189 @@
+// Package ethereum adapts Heimdallr to Ethereum mainnet (or any EVM chain
+// reachable the same way): it watches the bridge contract for ETH locks and
+// submits the unlock transactions that release them back to users. It is a
+// second, minimal EVM adapter alongside internal/avalanche, kept deliberately
+// small to prove out bridge.ChainAdapter rather than to be a fully-featured
+// deployment target.
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+)
+
+const assetETH = "ETH"
+
+// DefaultConfirmations is used if Config.Confirmations is zero.
+const DefaultConfirmations = 12
+
+// Config holds everything needed to talk to the Ethereum bridge contract.
+type Config struct {
+	RPCURL          string
+	ContractAddress common.Address
+	PrivateKey      string
+	ChainID         *big.Int
+
+	// Confirmations is how many blocks must bury a lock event before it is
+	// forwarded to the bridge. This guards against a re-org dropping the
+	// source transaction after we've already unlocked on the other chain.
+	Confirmations uint64
+
+	// StartBlock overrides the LastProcessedBlock cursor on the next
+	// Subscribe call, for initial deployment or disaster recovery. Zero
+	// means "use the stored cursor".
+	StartBlock uint64 `env:"ETHEREUM_START_BLOCK" envDefault:"0"`
+}
+
+func (c Config) confirmations() uint64 {
+	if c.Confirmations != 0 {
+		return c.Confirmations
+	}
+	return DefaultConfirmations
+}
+
+// Ethereum is the Heimdallr adapter for Ethereum.
+type Ethereum struct {
+	client        *ethclient.Client
+	contract      *bind.BoundContract
+	contractAddr  common.Address
+	abi           abi.ABI
+	signer        *bind.TransactOpts
+	confirmations uint64
+	startBlock    uint64
+
+	logger *zap.SugaredLogger
+}
+
+// bridgeABI describes only the event and method Heimdallr cares about.
+const bridgeABI = `[
+	{"type":"event","name":"ETHLocked","inputs":[{"name":"user","type":"address","indexed":true},{"name":"amount","type":"uint256"},{"name":"destination","type":"string"}]},
+	{"type":"function","name":"unlockETH","inputs":[{"name":"destination","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]}
+]`
+
+// New connects to the Ethereum RPC endpoint and prepares the bridge contract
+// binding used for both subscribing to locks and submitting unlocks.
+func New(ctx context.Context, cfg Config, logger *zap.SugaredLogger) (*Ethereum, error) {
+	client, err := ethclient.DialContext(ctx, cfg.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("dial ethereum rpc: %w", err)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(bridgeABI))
+	if err != nil {
+		return nil, fmt.Errorf("parse bridge abi: %w", err)
+	}
+
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.PrivateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+
+	signer, err := bind.NewKeyedTransactorWithChainID(key, cfg.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("build transactor: %w", err)
+	}
+
+	contract := bind.NewBoundContract(cfg.ContractAddress, parsed, client, client, client)
+
+	return &Ethereum{
+		client:        client,
+		contract:      contract,
+		contractAddr:  cfg.ContractAddress,
+		abi:           parsed,
+		signer:        signer,
+		confirmations: cfg.confirmations(),
+		startBlock:    cfg.StartBlock,
+		logger:        logger,
+	}, nil
+}
+
+// Name identifies this adapter to AssetRoute configuration and cursor keys.
+func (e *Ethereum) Name() string { return "ethereum" }
+
+// StartBlockOverride returns the operator-configured block to backfill
+// Subscribe from, bypassing the stored LastProcessedBlock cursor. Zero means
+// no override was configured.
+func (e *Ethereum) StartBlockOverride() uint64 {
+	return e.startBlock
+}
+
+func (e *Ethereum) filterQuery() ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{e.contractAddr},
+		Topics:    [][]common.Hash{{e.abi.Events["ETHLocked"].ID}},
+	}
+}
+
+func (e *Ethereum) decodeLog(log types.Log) (LockEvent, error) {
+	if log.Topics[0] != e.abi.Events["ETHLocked"].ID {
+		return LockEvent{}, fmt.Errorf("unrecognized log topic %s", log.Topics[0])
+	}
+
+	var decoded struct {
+		Amount      *big.Int
+		Destination string
+	}
+	if err := e.abi.UnpackIntoInterface(&decoded, "ETHLocked", log.Data); err != nil {
+		return LockEvent{}, fmt.Errorf("unpack ETHLocked log: %w", err)
+	}
+
+	user := common.HexToAddress(log.Topics[1].Hex())
+
+	return LockEvent{
+		asset:       assetETH,
+		user:        user.Hex(),
+		amount:      decoded.Amount,
+		destination: decoded.Destination,
+		blockHash:   log.BlockHash,
+		blockNumber: log.BlockNumber,
+		logIndex:    log.Index,
+		txHash:      log.TxHash,
+	}, nil
+}
+
+// Unlock releases previously-locked ETH to destination.
+func (e *Ethereum) Unlock(ctx context.Context, asset, destination string, amount *big.Int) (string, *big.Int, error) {
+	if asset != assetETH {
+		return "", nil, fmt.Errorf("ethereum adapter: unsupported asset %q", asset)
+	}
+
+	opts := *e.signer
+	opts.Context = ctx
+
+	tx, err := e.contract.Transact(&opts, "unlockETH", common.HexToAddress(destination), amount)
+	if err != nil {
+		return "", nil, fmt.Errorf("unlockETH: %w", err)
+	}
+
+	return tx.Hash().Hex(), tx.Cost(), nil
+}
+
+// Mint always fails: this adapter only ever unlocks natively-held ETH, it
+// never mints a wrapped asset.
+func (e *Ethereum) Mint(ctx context.Context, asset string, amount *big.Int) (string, *big.Int, error) {
+	return "", nil, fmt.Errorf("ethereum adapter does not mint %q; it only unlocks", asset)
+}
+
+// Transfer always fails, for the same reason as Mint.
+func (e *Ethereum) Transfer(ctx context.Context, asset, destination string, amount *big.Int) (string, *big.Int, error) {
+	return "", nil, fmt.Errorf("ethereum adapter does not transfer %q; use Unlock", asset)
+}
+
+// Landed always reports false: unlockETH doesn't yet take an idempotency
+// key, so there's nothing on-chain for this adapter to recognize a prior
+// attempt by. journaledStep falls back to its existing journal-only
+// behavior for this chain until the contract gains one.
+func (e *Ethereum) Landed(ctx context.Context, idempotencyKey string) (string, bool, error) {
+	return "", false, nil
+}
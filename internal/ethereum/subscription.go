@@ -0,0 +1,186 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// pollInterval is how often the subscription checks whether buffered events
+// have been buried deep enough, or re-orged out, since Ethereum exposes no
+// "finalized" notification this client relies on.
+const pollInterval = 2 * time.Second
+
+// pendingKey identifies a log within the chain's history. A re-org that
+// replaces a block invalidates every pendingKey minted against its hash,
+// which is exactly the property we need to safely drop orphaned events.
+type pendingKey struct {
+	blockHash common.Hash
+	logIndex  uint
+}
+
+// Subscription streams confirmed lock events off Ethereum. Raw logs are
+// buffered in-memory until Config.Confirmations blocks have buried them;
+// only then are they forwarded on Locked. Events whose block is re-orged
+// away are dropped and reported on Err() instead.
+type Subscription struct {
+	locked chan LockEvent
+	err    chan error
+
+	logsSub ethereum.Subscription
+	cancel  context.CancelFunc
+}
+
+func (s *Subscription) Locked() <-chan LockEvent { return s.locked }
+func (s *Subscription) Err() <-chan error         { return s.err }
+
+// Close tears down the underlying log subscription and confirmation poller.
+func (s *Subscription) Close() {
+	s.cancel()
+	s.logsSub.Unsubscribe()
+}
+
+// Subscribe streams lock events off Ethereum. If from is non-zero, it first
+// backfills every ETHLocked log between from and the current head via
+// FilterLogs, so downtime doesn't silently drop events, before merging into
+// the live subscription.
+func (e *Ethereum) Subscribe(ctx context.Context, from uint64) (*Subscription, error) {
+	logs := make(chan types.Log, 256)
+	logsSub, err := e.client.SubscribeFilterLogs(ctx, e.filterQuery(), logs)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe filter logs: %w", err)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	sub := &Subscription{
+		locked:  make(chan LockEvent),
+		err:     make(chan error, 1),
+		logsSub: logsSub,
+		cancel:  cancel,
+	}
+
+	backfill, err := e.backfill(ctx, from)
+	if err != nil {
+		cancel()
+		logsSub.Unsubscribe()
+		return nil, fmt.Errorf("backfill lock events: %w", err)
+	}
+
+	go e.run(subCtx, sub, backfill, logs)
+
+	return sub, nil
+}
+
+// backfill fetches every lock log between from and the current head. It
+// returns nil without querying anything if from is zero, meaning the caller
+// has no prior cursor and wants to start at the chain tip.
+func (e *Ethereum) backfill(ctx context.Context, from uint64) ([]types.Log, error) {
+	if from == 0 {
+		return nil, nil
+	}
+
+	head, err := e.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch head header: %w", err)
+	}
+	if from > head.Number.Uint64() {
+		return nil, nil
+	}
+
+	query := e.filterQuery()
+	query.FromBlock = new(big.Int).SetUint64(from)
+	query.ToBlock = head.Number
+
+	logs, err := e.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("filter logs from %d to %d: %w", from, head.Number.Uint64(), err)
+	}
+
+	return logs, nil
+}
+
+// run decodes raw logs into pending events and releases them once they're
+// buried by the configured confirmation depth, or drops them and reports a
+// re-org if the block that contained them stops being canonical. backfill is
+// processed before the live logs channel is drained at all.
+func (e *Ethereum) run(ctx context.Context, sub *Subscription, backfill []types.Log, logs <-chan types.Log) {
+	pending := make(map[pendingKey]LockEvent)
+
+	for _, log := range backfill {
+		e.bufferLog(pending, log)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case err := <-sub.logsSub.Err():
+			if err != nil {
+				sub.err <- fmt.Errorf("log subscription: %w", err)
+			}
+
+		case log := <-logs:
+			e.bufferLog(pending, log)
+
+		case <-ticker.C:
+			e.releaseConfirmed(ctx, sub, pending)
+		}
+	}
+}
+
+func (e *Ethereum) bufferLog(pending map[pendingKey]LockEvent, log types.Log) {
+	event, err := e.decodeLog(log)
+	if err != nil {
+		e.logger.Errorf("decode lock log: %s", err)
+		return
+	}
+
+	if log.Removed {
+		// Already known to be re-orged out before we even buffered it.
+		return
+	}
+
+	pending[event.key()] = event
+}
+
+func (e *Ethereum) releaseConfirmed(ctx context.Context, sub *Subscription, pending map[pendingKey]LockEvent) {
+	head, err := e.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		e.logger.Errorf("fetch head header: %s", err)
+		return
+	}
+
+	for key, event := range pending {
+		if head.Number.Uint64() < event.blockNumber+e.confirmations {
+			continue
+		}
+
+		canonical, err := e.client.HeaderByNumber(ctx, new(big.Int).SetUint64(event.blockNumber))
+		if err != nil {
+			e.logger.Errorf("fetch header %d: %s", event.blockNumber, err)
+			continue
+		}
+
+		if canonical.Hash() != key.blockHash {
+			e.logger.Warnw("lock event re-orged out, dropping",
+				"tx_hash", event.txHash.Hex(),
+				"block", event.blockNumber,
+			)
+			sub.err <- fmt.Errorf("reorged: lock at block %d (tx %s) is no longer canonical", event.blockNumber, event.txHash.Hex())
+			delete(pending, key)
+			continue
+		}
+
+		sub.locked <- event
+		delete(pending, key)
+	}
+}
@@ -0,0 +1,132 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	gethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// evmBumpNum/evmBumpDenom scale a stalled transaction's fee cap by 1.125x
+// (9/8), the minimum bump go-ethereum's mempool requires to accept a
+// replacement transaction at the same nonce.
+const (
+	evmBumpNum   = 9
+	evmBumpDenom = 8
+)
+
+// EVMFees is one attempt's gas pricing for an EIP-1559 transaction.
+type EVMFees struct {
+	TipCap *big.Int // maxPriorityFeePerGas
+	FeeCap *big.Int // maxFeePerGas
+}
+
+// EVMHeaderSource is the subset of an ethclient.Client EstimateEVM needs,
+// narrowed so it can be faked without dialing a real node.
+type EVMHeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+}
+
+// EstimateEVM computes this attempt's EVMFees from the chain's suggested
+// priority fee and the current head's base fee: maxFeePerGas = 2*baseFee +
+// tip, capped at ceiling if set. Doubling the base fee rides out a couple of
+// blocks of EIP-1559's 12.5%-per-block increase without needing a
+// replacement.
+func EstimateEVM(ctx context.Context, client EVMHeaderSource, ceiling *big.Int) (EVMFees, error) {
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return EVMFees{}, fmt.Errorf("fetch head header: %w", err)
+	}
+	if head.BaseFee == nil {
+		return EVMFees{}, fmt.Errorf("chain head has no base fee; not an EIP-1559 chain")
+	}
+
+	tip, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return EVMFees{}, fmt.Errorf("suggest gas tip cap: %w", err)
+	}
+
+	feeCap := new(big.Int).Add(new(big.Int).Mul(big.NewInt(2), head.BaseFee), tip)
+	if ceiling != nil && feeCap.Cmp(ceiling) > 0 {
+		feeCap = new(big.Int).Set(ceiling)
+	}
+
+	return EVMFees{TipCap: tip, FeeCap: feeCap}, nil
+}
+
+// Bump scales f's tip cap and fee cap by 1.125x for a replacement attempt at
+// the same nonce, capped at ceiling if set.
+func (f EVMFees) Bump(ceiling *big.Int) EVMFees {
+	bumped := EVMFees{
+		TipCap: scale(f.TipCap, evmBumpNum, evmBumpDenom),
+		FeeCap: scale(f.FeeCap, evmBumpNum, evmBumpDenom),
+	}
+	if ceiling != nil && bumped.FeeCap.Cmp(ceiling) > 0 {
+		bumped.FeeCap = new(big.Int).Set(ceiling)
+	}
+	return bumped
+}
+
+func scale(n *big.Int, num, denom int64) *big.Int {
+	return new(big.Int).Div(new(big.Int).Mul(n, big.NewInt(num)), big.NewInt(denom))
+}
+
+// EVMReceiptSource is the subset of an ethclient.Client needed to check
+// whether a submitted transaction has been included, and to advance
+// WaitNextEVMBlock's notion of the chain head.
+type EVMReceiptSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// EVMTx adapts a submitted *types.Transaction to the Tx interface
+// SendWithReplacement watches.
+type EVMTx struct {
+	Transaction *types.Transaction
+	Client      EVMReceiptSource
+}
+
+func (t EVMTx) Hash() string { return t.Transaction.Hash().Hex() }
+
+// Fee is the maximum this attempt could cost (gasFeeCap * gasLimit), since
+// the actual amount burned isn't known until it's included.
+func (t EVMTx) Fee() *big.Int {
+	return new(big.Int).Mul(t.Transaction.GasFeeCap(), new(big.Int).SetUint64(t.Transaction.Gas()))
+}
+
+func (t EVMTx) Included(ctx context.Context) (bool, error) {
+	_, err := t.Client.TransactionReceipt(ctx, t.Transaction.Hash())
+	if errors.Is(err, gethereum.NotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// WaitNextEVMBlock returns a fees.WaitNext that blocks until client's head
+// block number advances, for use as SendWithReplacement's confirmation
+// clock on an EVM chain.
+func WaitNextEVMBlock(client EVMReceiptSource) WaitNext {
+	return func(ctx context.Context) error {
+		head, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("fetch head header: %w", err)
+		}
+		start := head.Number.Uint64()
+
+		return pollUntil(ctx, func() (bool, error) {
+			head, err := client.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return false, fmt.Errorf("fetch head header: %w", err)
+			}
+			return head.Number.Uint64() > start, nil
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Package fees estimates transaction/operation fees on the chains Heimdallr
+// writes to, and rescues a submission that gets stuck behind a fee spike by
+// rebroadcasting it at a bumped fee instead of leaving a swap half-finished.
+package fees
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// DefaultReplaceAfter is used when a chain's Config leaves ReplaceAfter at
+// zero.
+const DefaultReplaceAfter = 3
+
+// DefaultMaxReplacements is used when a chain's Config leaves MaxReplacements
+// at zero.
+const DefaultMaxReplacements = 5
+
+// Config bounds how SendWithReplacement rescues a stalled transaction.
+type Config struct {
+	// ReplaceAfter is how many blocks/levels a transaction may sit
+	// unconfirmed before it's rebroadcast at a bumped fee. Zero means
+	// DefaultReplaceAfter.
+	ReplaceAfter uint64
+
+	// MaxReplacements bounds how many times one transaction is rebroadcast
+	// before SendWithReplacement gives up and returns an error. Zero means
+	// DefaultMaxReplacements.
+	MaxReplacements int
+
+	// Ceiling caps the fee a bumped EVM attempt may offer, so a long run of
+	// replacements can't run away with the operator's balance. Nil means no
+	// cap. Tezos's fixed per-replacement bump is never capped.
+	Ceiling *big.Int
+}
+
+func (c Config) replaceAfter() uint64 {
+	if c.ReplaceAfter != 0 {
+		return c.ReplaceAfter
+	}
+	return DefaultReplaceAfter
+}
+
+func (c Config) maxReplacements() int {
+	if c.MaxReplacements != 0 {
+		return c.MaxReplacements
+	}
+	return DefaultMaxReplacements
+}
+
+// FeeParams is one attempt's fee pricing. Exactly one of EVM or Tezos is
+// set, matching whichever chain's Estimate* produced it.
+type FeeParams struct {
+	EVM   *EVMFees
+	Tezos *TezosFees
+}
+
+// Bump scales params up for a replacement attempt at the same nonce/counter:
+// 1.125x on EVM (capped at ceiling), +100 mutez on Tezos.
+func (p FeeParams) Bump(ceiling *big.Int) FeeParams {
+	switch {
+	case p.EVM != nil:
+		bumped := p.EVM.Bump(ceiling)
+		return FeeParams{EVM: &bumped}
+	case p.Tezos != nil:
+		bumped := p.Tezos.Bump()
+		return FeeParams{Tezos: &bumped}
+	default:
+		return p
+	}
+}
+
+// Tx is one submitted attempt at a transaction/operation, as handed back by
+// the buildTx closure passed to SendWithReplacement.
+type Tx interface {
+	// Hash identifies this attempt, e.g. a C-Chain tx hash or a Tezos op
+	// hash.
+	Hash() string
+
+	// Fee is the total fee this attempt will cost if it lands, or an upper
+	// bound on it where the exact amount isn't known before inclusion.
+	Fee() *big.Int
+
+	// Included reports whether this attempt has landed on-chain yet.
+	Included(ctx context.Context) (bool, error)
+}
+
+// WaitNext blocks until the next block/level has been produced, so
+// SendWithReplacement can count confirmations without busy-polling. Each
+// chain adapter supplies its own.
+type WaitNext func(ctx context.Context) error
+
+// SendWithReplacement submits buildTx's first attempt at params, then
+// watches it for inclusion. If ReplaceAfter blocks/levels pass without it
+// landing, it rebuilds at a bumped fee (buildTx is expected to reuse
+// whatever nonce/counter it already fixed for the first attempt) and
+// watches that instead, up to MaxReplacements times. It returns the hash and
+// total fee of whichever attempt actually lands, so a crash-safe caller can
+// journal it exactly as it would a fire-and-forget submit.
+func SendWithReplacement(ctx context.Context, cfg Config, wait WaitNext, params FeeParams, buildTx func(FeeParams) (Tx, error)) (string, *big.Int, error) {
+	tx, err := buildTx(params)
+	if err != nil {
+		return "", nil, fmt.Errorf("build tx: %w", err)
+	}
+
+	for attempt := 0; ; {
+		hash, fee, included, err := awaitInclusion(ctx, wait, cfg.replaceAfter(), tx)
+		if err != nil {
+			return "", nil, err
+		}
+		if included {
+			return hash, fee, nil
+		}
+
+		if attempt >= cfg.maxReplacements() {
+			return "", nil, fmt.Errorf("tx %s not included after %d replacements", tx.Hash(), attempt)
+		}
+		attempt++
+
+		params = params.Bump(cfg.Ceiling)
+		tx, err = buildTx(params)
+		if err != nil {
+			return "", nil, fmt.Errorf("rebuild tx at bumped fee (attempt %d): %w", attempt, err)
+		}
+	}
+}
+
+// awaitInclusion polls tx for up to replaceAfter blocks/levels, sleeping
+// between checks via wait.
+func awaitInclusion(ctx context.Context, wait WaitNext, replaceAfter uint64, tx Tx) (hash string, fee *big.Int, included bool, err error) {
+	for i := uint64(0); i < replaceAfter; i++ {
+		ok, err := tx.Included(ctx)
+		if err != nil {
+			return "", nil, false, fmt.Errorf("check inclusion of %s: %w", tx.Hash(), err)
+		}
+		if ok {
+			return tx.Hash(), tx.Fee(), true, nil
+		}
+
+		if err := wait(ctx); err != nil {
+			return "", nil, false, fmt.Errorf("wait for next block: %w", err)
+		}
+	}
+
+	return "", nil, false, nil
+}
@@ -0,0 +1,80 @@
+package fees
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTx struct {
+	hash      string
+	fee       int64
+	includeAt int
+	// checks is shared across every rebuild of the same logical attempt, so
+	// includeAt counts checks cumulatively rather than resetting each time
+	// SendWithReplacement rebuilds at a bumped fee.
+	checks *int
+}
+
+func (t *fakeTx) Hash() string   { return t.hash }
+func (t *fakeTx) Fee() *big.Int { return big.NewInt(t.fee) }
+
+func (t *fakeTx) Included(ctx context.Context) (bool, error) {
+	*t.checks++
+	return *t.checks > t.includeAt, nil
+}
+
+func TestSendWithReplacementBumpsFeeUntilIncluded(t *testing.T) {
+	var built []*fakeTx
+	checks := 0
+	buildTx := func(p FeeParams) (Tx, error) {
+		tx := &fakeTx{hash: "attempt", fee: p.EVM.FeeCap.Int64(), includeAt: 10, checks: &checks}
+		built = append(built, tx)
+		return tx, nil
+	}
+
+	cfg := Config{ReplaceAfter: 2, MaxReplacements: 5}
+	params := FeeParams{EVM: &EVMFees{TipCap: big.NewInt(1), FeeCap: big.NewInt(100)}}
+
+	hash, fee, err := SendWithReplacement(context.Background(), cfg, func(context.Context) error { return nil }, params, buildTx)
+	require.NoError(t, err)
+	require.Equal(t, "attempt", hash)
+
+	// The tx "lands" on the 11th check overall, but each attempt only gets
+	// ReplaceAfter=2 checks before being replaced, so this must have taken
+	// several rebuilds at a bumped fee to finally clear includeAt.
+	require.Greater(t, len(built), 1)
+	require.Greater(t, fee.Int64(), int64(100))
+}
+
+func TestSendWithReplacementGivesUpAfterMaxReplacements(t *testing.T) {
+	checks := 0
+	buildTx := func(p FeeParams) (Tx, error) {
+		return &fakeTx{hash: "attempt", fee: p.EVM.FeeCap.Int64(), includeAt: 1000, checks: &checks}, nil
+	}
+
+	cfg := Config{ReplaceAfter: 1, MaxReplacements: 2}
+	params := FeeParams{EVM: &EVMFees{TipCap: big.NewInt(1), FeeCap: big.NewInt(100)}}
+
+	_, _, err := SendWithReplacement(context.Background(), cfg, func(context.Context) error { return nil }, params, buildTx)
+	require.Error(t, err)
+}
+
+type fakeSimulator struct {
+	gas, storage int64
+}
+
+func (s fakeSimulator) Simulate(ctx context.Context) (int64, int64, error) {
+	return s.gas, s.storage, nil
+}
+
+func TestEstimateTezosPadsAndPricesFromGas(t *testing.T) {
+	fees, err := EstimateTezos(context.Background(), fakeSimulator{gas: 1000, storage: 200})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1100), fees.GasLimit)     // +10% margin
+	require.Equal(t, int64(220), fees.StorageLimit)   // +10% margin
+	require.Equal(t, tezosBaseFeeMutez+110, int(fees.Fee)) // 100 + 0.1*1100
+}
@@ -0,0 +1,32 @@
+package fees
+
+import (
+	"context"
+	"time"
+)
+
+// pollInterval is how often WaitNextEVMBlock re-checks the chain head while
+// waiting for it to advance.
+const pollInterval = 2 * time.Second
+
+// pollUntil calls done every pollInterval until it reports true, ctx is
+// cancelled, or it returns an error.
+func pollUntil(ctx context.Context, done func() (bool, error)) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			ok, err := done()
+			if err != nil {
+				return err
+			}
+			if ok {
+				return nil
+			}
+		}
+	}
+}
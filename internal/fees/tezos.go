@@ -0,0 +1,74 @@
+package fees
+
+import (
+	"context"
+	"fmt"
+)
+
+// tezosSafetyMarginPct pads a run_operation simulation's reported gas and
+// storage consumption, since a real injection can run slightly hotter than a
+// dry run if other operations land in the same block first.
+const tezosSafetyMarginPct = 10
+
+// tezosFeeBumpMutez is how much TezosFees.Bump adds to Fee for a replacement
+// operation at the same counter.
+const tezosFeeBumpMutez = 100
+
+// tezosBaseFeeMutez and tezosMutezPerGasUnit mirror octez-client's default
+// minimal-fee heuristic. A Tezos node's run_operation simulation reports how
+// much gas and storage an operation consumes, but — unlike an EVM chain's
+// base fee — it doesn't suggest a fee; callers are expected to price the
+// operation themselves from that consumption.
+const (
+	tezosBaseFeeMutez    = 100
+	tezosMutezPerGasUnit = 0.1
+)
+
+// TezosFees is one attempt's fee/gas/storage pricing for a Tezos operation,
+// derived from a run_operation simulation.
+type TezosFees struct {
+	Fee          int64 // mutez
+	GasLimit     int64
+	StorageLimit int64
+}
+
+// TezosSimulator runs a Tezos node's run_operation simulation for one
+// pending operation and reports how much gas and storage it actually
+// consumed.
+type TezosSimulator interface {
+	Simulate(ctx context.Context) (consumedGas, consumedStorage int64, err error)
+}
+
+// EstimateTezos runs sim's run_operation simulation, pads its reported
+// gas/storage consumption by a safety margin, and derives a fee from the
+// padded gas limit using the minimal-fee heuristic above.
+func EstimateTezos(ctx context.Context, sim TezosSimulator) (TezosFees, error) {
+	gas, storage, err := sim.Simulate(ctx)
+	if err != nil {
+		return TezosFees{}, fmt.Errorf("simulate operation: %w", err)
+	}
+
+	gasLimit := withMargin(gas)
+	fee := tezosBaseFeeMutez + int64(float64(gasLimit)*tezosMutezPerGasUnit)
+
+	return TezosFees{
+		Fee:          fee,
+		GasLimit:     gasLimit,
+		StorageLimit: withMargin(storage),
+	}, nil
+}
+
+func withMargin(n int64) int64 {
+	return n + (n*tezosSafetyMarginPct)/100
+}
+
+// Bump adds tezosFeeBumpMutez to f.Fee for a replacement operation at the
+// same counter. Gas/storage limits are left as-is: congestion raises the
+// price of inclusion, it doesn't change what the operation costs to run.
+func (f TezosFees) Bump() TezosFees {
+	return TezosFees{
+		Fee:          f.Fee + tezosFeeBumpMutez,
+		GasLimit:     f.GasLimit,
+		StorageLimit: f.StorageLimit,
+	}
+}
@@ -0,0 +1,53 @@
+package tezos
+
+import (
+	"fmt"
+	"math/big"
+
+	tzt "blockwatch.cc/tzgo/tezos"
+)
+
+// BurnEvent is emitted when a user burns wrapped WAVAX or WUSDC on Tezos to
+// redeem the underlying asset on the C-Chain. It satisfies bridge.Event
+// structurally so it can be handed straight to the bridge's Atomic
+// operations without this package importing bridge.
+type BurnEvent struct {
+	asset       string
+	user        string
+	amount      *big.Int
+	destination string
+
+	blockHash tzt.BlockHash
+	level     int64
+	opHash    tzt.OpHash
+}
+
+func (e BurnEvent) User() string        { return e.user }
+func (e BurnEvent) Amount() *big.Int    { return e.amount }
+func (e BurnEvent) Destination() string { return e.destination }
+
+// Asset is the symbol that was burned, e.g. "WAVAX" or "WUSDC".
+func (e BurnEvent) Asset() string { return e.asset }
+
+// Key returns a stable idempotency key for this burn, derived from the
+// source operation hash, so the same event always journals to the same swap
+// even if it is redelivered or replayed.
+func (e BurnEvent) Key() string {
+	return fmt.Sprintf("tezos:%s", e.opHash.String())
+}
+
+// Position is the Tezos block level this burn was included at, used to
+// advance the per-asset LastProcessedBlock cursor once its Operation
+// completes.
+func (e BurnEvent) Position() uint64 {
+	return uint64(e.level)
+}
+
+// key identifies the operation a BurnEvent was decoded from. It is stable
+// across re-orgs that don't touch this exact block.
+//
+// tzt.BlockHash/tzt.OpHash wrap a byte slice and so aren't comparable; the
+// key is built from their base58 string form instead so it can back a map.
+func (e BurnEvent) key() pendingKey {
+	return pendingKey{blockHash: e.blockHash.String(), opHash: e.opHash.String()}
+}
@@ -0,0 +1,59 @@
+package tezos
+
+import (
+	"fmt"
+	"math/big"
+
+	"blockwatch.cc/tzgo/micheline"
+)
+
+// decodeBurnParams extracts the amount and C-Chain destination address from
+// a burn_wavax/burn_wusdc entrypoint call, encoded as a Michelson pair
+// (nat, string).
+func decodeBurnParams(value micheline.Prim) (*big.Int, string, error) {
+	if len(value.Args) != 2 {
+		return nil, "", fmt.Errorf("expected 2 args, got %d", len(value.Args))
+	}
+
+	amount := value.Args[0].Int
+	if amount == nil {
+		return nil, "", fmt.Errorf("missing amount")
+	}
+
+	destination := value.Args[1].String
+	if destination == "" {
+		return nil, "", fmt.Errorf("missing destination")
+	}
+
+	return amount, destination, nil
+}
+
+// callParams builds the Michelson parameters for a mint/transfer entrypoint
+// call, encoded as a pair (nat, string) mirroring decodeBurnParams.
+func callParams(entrypoint string, amount *big.Int, destination string) *micheline.Parameters {
+	return &micheline.Parameters{
+		Entrypoint: entrypoint,
+		Value: micheline.NewPair(
+			micheline.NewNat(amount),
+			micheline.NewString(destination),
+		),
+	}
+}
+
+// callParamsWithApprovals builds the Michelson parameters for the
+// "_with_approvals" variant of a mint/transfer entrypoint, which takes the
+// same (nat, string) pair plus the federation's signed approval bundle as a
+// raw bytes argument, so it lands in the operation's own parameters for later
+// audit.
+func callParamsWithApprovals(entrypoint string, amount *big.Int, destination string, approvals []byte) *micheline.Parameters {
+	return &micheline.Parameters{
+		Entrypoint: entrypoint + "_with_approvals",
+		Value: micheline.NewPair(
+			micheline.NewPair(
+				micheline.NewNat(amount),
+				micheline.NewString(destination),
+			),
+			micheline.NewBytes(approvals),
+		),
+	}
+}
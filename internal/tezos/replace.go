@@ -0,0 +1,148 @@
+package tezos
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"blockwatch.cc/tzgo/codec"
+	"blockwatch.cc/tzgo/rpc"
+	tzt "blockwatch.cc/tzgo/tezos"
+)
+
+// tezosSim runs op through the node's run_operation simulation endpoint to
+// satisfy fees.TezosSimulator, without signing or broadcasting anything.
+type tezosSim struct {
+	tezos *Tezos
+	op    *codec.Op
+}
+
+// runOperationResult is the subset of run_operation's response this package
+// reads: each content's reported gas and storage consumption, per the
+// Tezos RPC's standard operation_result shape.
+type runOperationResult struct {
+	Contents []struct {
+		Metadata struct {
+			OperationResult struct {
+				ConsumedMilligas    string `json:"consumed_milligas"`
+				PaidStorageSizeDiff string `json:"paid_storage_size_diff"`
+			} `json:"operation_result"`
+		} `json:"metadata"`
+	} `json:"contents"`
+}
+
+func (s tezosSim) Simulate(ctx context.Context) (consumedGas, consumedStorage int64, err error) {
+	op := s.op
+	if !op.Branch.IsValid() {
+		head, err := s.tezos.client.GetHeadBlock(ctx)
+		if err != nil {
+			return 0, 0, fmt.Errorf("fetch head block: %w", err)
+		}
+		op = op.WithBranch(head.Hash)
+	}
+
+	req := rpc.RunOperationRequest{Operation: op, ChainId: s.tezos.client.ChainId}
+
+	var result runOperationResult
+	if err := s.tezos.client.RunOperation(ctx, rpc.Head, req, &result); err != nil {
+		return 0, 0, fmt.Errorf("run_operation: %w", err)
+	}
+
+	for _, content := range result.Contents {
+		milligas, _ := strconv.ParseInt(content.Metadata.OperationResult.ConsumedMilligas, 10, 64)
+		storage, _ := strconv.ParseInt(content.Metadata.OperationResult.PaidStorageSizeDiff, 10, 64)
+		consumedGas += milligas / 1000
+		consumedStorage += storage
+	}
+
+	return consumedGas, consumedStorage, nil
+}
+
+// tezosTx adapts a broadcast operation to the fees.Tx interface
+// SendWithReplacement watches. The node exposes no direct "is this hash
+// included" lookup, so Included scans each new block since the operation's
+// submission level for its hash.
+type tezosTx struct {
+	tezos       *Tezos
+	hash        tzt.OpHash
+	fee         int64
+	fromLevel   int64
+	lastChecked int64
+}
+
+func (t *tezosTx) Hash() string { return t.hash.String() }
+
+func (t *tezosTx) Fee() *big.Int { return big.NewInt(t.fee) }
+
+func (t *tezosTx) Included(ctx context.Context) (bool, error) {
+	head, err := t.tezos.client.GetHeadBlock(ctx)
+	if err != nil {
+		return false, fmt.Errorf("fetch head block: %w", err)
+	}
+
+	from := t.fromLevel
+	if t.lastChecked+1 > from {
+		from = t.lastChecked + 1
+	}
+
+	for level := from; level <= head.Header.Level; level++ {
+		block, err := t.tezos.client.GetBlock(ctx, rpc.BlockLevel(level))
+		if err != nil {
+			return false, fmt.Errorf("fetch block %d: %w", level, err)
+		}
+
+		for _, pass := range block.Operations {
+			for _, op := range pass {
+				if op.Hash.String() == t.hash.String() {
+					t.lastChecked = head.Header.Level
+					return true, nil
+				}
+			}
+		}
+	}
+	t.lastChecked = head.Header.Level
+
+	return false, nil
+}
+
+// Landed always reports false: tagging an outgoing call with an idempotency
+// key would mean wrapping its Michelson parameters in an extra pair, which
+// changes the entrypoint's parameter type without changing its name, and
+// the bridge contract would reject it as a type mismatch. Until the
+// contract's entrypoints are extended to actually accept a key argument,
+// there's nothing on-chain for this adapter to recognize a prior attempt
+// by. journaledStep falls back to its existing journal-only behavior for
+// this chain, same as avalanche.Avalanche.Landed and ethereum.Ethereum.Landed.
+func (t *Tezos) Landed(ctx context.Context, idempotencyKey string) (string, bool, error) {
+	return "", false, nil
+}
+
+// waitNextLevel blocks until the chain head advances past its level at call
+// time, for use as SendWithReplacement's confirmation clock on Tezos.
+func (t *Tezos) waitNextLevel(ctx context.Context) error {
+	head, err := t.client.GetHeadBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("fetch head block: %w", err)
+	}
+	start := head.Header.Level
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			head, err := t.client.GetHeadBlock(ctx)
+			if err != nil {
+				continue
+			}
+			if head.Header.Level > start {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package tezos
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// pollInterval is how often the subscription checks for new blocks and for
+// buffered events that have either been buried deep enough or re-orged out.
+// Tezos produces blocks roughly every 8s, so there's no point polling faster.
+const pollInterval = 5 * time.Second
+
+// pendingKey identifies the operation a BurnEvent was decoded from. A re-org
+// that replaces a block invalidates every pendingKey minted against its
+// hash, which is how the subscription safely drops orphaned events.
+//
+// Fields are the base58 string form of the underlying hashes rather than
+// tzt.BlockHash/tzt.OpHash themselves, since those wrap a byte slice and
+// aren't comparable.
+type pendingKey struct {
+	blockHash string
+	opHash    string
+}
+
+type pendingEvent struct {
+	asset string
+	event BurnEvent
+}
+
+// Subscription streams confirmed burn events off Tezos. Raw operations are
+// buffered in-memory until SourceConfirmations[asset] levels have buried
+// them; only then are they forwarded on Burned, each tagged with its asset.
+// Events whose block is re-orged away are dropped and reported on Err()
+// instead.
+type Subscription struct {
+	burned chan BurnEvent
+	err    chan error
+
+	cancel context.CancelFunc
+}
+
+func (s *Subscription) Burned() <-chan BurnEvent { return s.burned }
+func (s *Subscription) Err() <-chan error         { return s.err }
+
+// Close stops the block poller backing this subscription.
+func (s *Subscription) Close() {
+	s.cancel()
+}
+
+// Subscribe streams burn events off Tezos. If from is non-zero and not
+// already past the current head, run scans every block from from onward
+// instead of just the tip, so downtime doesn't silently drop burns.
+func (t *Tezos) Subscribe(ctx context.Context, from int64) (*Subscription, error) {
+	head, err := t.client.GetHeadBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch head block: %w", err)
+	}
+
+	startLevel := head.Header.Level
+	if from > 0 && from <= head.Header.Level {
+		startLevel = from - 1
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	sub := &Subscription{
+		burned: make(chan BurnEvent),
+		err:    make(chan error, 1),
+		cancel: cancel,
+	}
+
+	go t.run(subCtx, sub, startLevel)
+
+	return sub, nil
+}
+
+// run scans each new block for burn operations against the bridge contract,
+// buffers them, and releases (or drops, on re-org) buffered events once
+// they've crossed the configured confirmation depth.
+func (t *Tezos) run(ctx context.Context, sub *Subscription, fromLevel int64) {
+	pending := make(map[pendingKey]pendingEvent)
+	nextLevel := fromLevel + 1
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			head, err := t.client.GetHeadBlock(ctx)
+			if err != nil {
+				sub.err <- fmt.Errorf("fetch head block: %w", err)
+				continue
+			}
+
+			for ; nextLevel <= head.Header.Level; nextLevel++ {
+				events, err := t.scanBlock(ctx, nextLevel)
+				if err != nil {
+					sub.err <- fmt.Errorf("scan block %d: %w", nextLevel, err)
+					break
+				}
+				for _, pe := range events {
+					pending[pe.event.key()] = pe
+				}
+			}
+
+			t.releaseConfirmed(ctx, sub, head.Header.Level, pending)
+		}
+	}
+}
+
+func (t *Tezos) releaseConfirmed(ctx context.Context, sub *Subscription, head int64, pending map[pendingKey]pendingEvent) {
+	for key, pe := range pending {
+		required := t.confirmations.forAsset(pe.asset)
+		if head-pe.event.level < int64(required) {
+			continue
+		}
+
+		canonical, err := t.blockHashAt(ctx, pe.event.level)
+		if err != nil {
+			t.logger.Errorf("fetch block hash at level %d: %s", pe.event.level, err)
+			continue
+		}
+
+		if canonical.String() != key.blockHash {
+			t.logger.Warnw("burn event re-orged out, dropping",
+				"asset", pe.asset,
+				"op_hash", pe.event.opHash.String(),
+				"level", pe.event.level,
+			)
+			sub.err <- fmt.Errorf("reorged: %s burn at level %d (op %s) is no longer canonical", pe.asset, pe.event.level, pe.event.opHash.String())
+			delete(pending, key)
+			continue
+		}
+
+		sub.burned <- pe.event
+		delete(pending, key)
+	}
+}
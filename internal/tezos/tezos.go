@@ -0,0 +1,307 @@
+// Package tezos adapts Heimdallr to Tezos: it watches the bridge contract
+// for WAVAX/WUSDC burns and submits the mint/transfer operations that wrap
+// assets locked on the C-Chain.
+package tezos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"blockwatch.cc/tzgo/codec"
+	"blockwatch.cc/tzgo/rpc"
+	tzt "blockwatch.cc/tzgo/tezos"
+	"go.uber.org/zap"
+	"heimdallr/internal/bridge/federation"
+	"heimdallr/internal/fees"
+)
+
+const (
+	assetWAVAX = "WAVAX"
+	assetWUSDC = "WUSDC"
+)
+
+// DefaultConfirmations is used for any asset not given an explicit entry in
+// SourceConfirmations.
+const DefaultConfirmations = 2
+
+// simGasLimit/simStorageLimit are the protocol's hard per-operation caps,
+// used as placeholder limits on the dry-run operation passed to
+// run_operation so the simulation doesn't run out of gas/storage before
+// reporting how much the real call actually needs.
+const (
+	simGasLimit     = 1040000
+	simStorageLimit = 60000
+)
+
+// SourceConfirmations configures, per asset symbol, how many Tezos levels
+// must bury a burn operation before it is forwarded to the bridge. This
+// guards against a re-org dropping the source operation after we've already
+// unlocked the underlying asset on the C-Chain.
+type SourceConfirmations map[string]uint64
+
+func (c SourceConfirmations) forAsset(asset string) uint64 {
+	if n, ok := c[asset]; ok {
+		return n
+	}
+	return DefaultConfirmations
+}
+
+// Config holds everything needed to talk to the Tezos bridge contract.
+type Config struct {
+	RPCURL          string
+	ContractAddress tzt.Address
+	PrivateKey      string // base58-encoded secret key
+
+	// SourceConfirmations overrides DefaultConfirmations per asset.
+	SourceConfirmations SourceConfirmations
+
+	// StartLevel overrides the LastProcessedBlock cursor on the next
+	// Subscribe call, for initial deployment or disaster recovery. Zero
+	// means "use the stored cursor".
+	StartLevel int64 `env:"TEZOS_START_LEVEL" envDefault:"0"`
+
+	// ReplaceAfter is how many levels a submitted mint/transfer may sit
+	// unconfirmed before it's rebroadcast at a bumped fee. Zero means
+	// fees.DefaultReplaceAfter.
+	ReplaceAfter uint64
+
+	// MaxReplacements bounds how many times one operation is rebroadcast
+	// before giving up. Zero means fees.DefaultMaxReplacements.
+	MaxReplacements int
+}
+
+// Tezos is the Heimdallr adapter for Tezos.
+type Tezos struct {
+	client        *rpc.Client
+	contractAddr  tzt.Address
+	key           tzt.PrivateKey
+	address       tzt.Address
+	confirmations SourceConfirmations
+	startLevel    int64
+
+	replacement fees.Config
+
+	logger *zap.SugaredLogger
+}
+
+// New connects to the Tezos RPC node and prepares the signer used to submit
+// mint/transfer operations against the bridge contract.
+func New(ctx context.Context, cfg Config, logger *zap.SugaredLogger) (*Tezos, error) {
+	client, err := rpc.NewClient(cfg.RPCURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("dial tezos rpc: %w", err)
+	}
+	if err := client.Init(ctx); err != nil {
+		return nil, fmt.Errorf("init tezos rpc: %w", err)
+	}
+
+	key, err := tzt.ParsePrivateKey(cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+
+	return &Tezos{
+		client:        client,
+		contractAddr:  cfg.ContractAddress,
+		key:           key,
+		address:       key.Address(),
+		confirmations: cfg.SourceConfirmations,
+		startLevel:    cfg.StartLevel,
+		replacement: fees.Config{
+			ReplaceAfter:    cfg.ReplaceAfter,
+			MaxReplacements: cfg.MaxReplacements,
+		},
+		logger: logger,
+	}, nil
+}
+
+// Name identifies this adapter to AssetRoute configuration and cursor keys.
+func (t *Tezos) Name() string { return "tezos" }
+
+// StartLevelOverride returns the operator-configured level to backfill
+// Subscribe from, bypassing the stored LastProcessedBlock cursor. Zero means
+// no override was configured.
+func (t *Tezos) StartLevelOverride() int64 {
+	return t.startLevel
+}
+
+func (t *Tezos) blockHashAt(ctx context.Context, level int64) (tzt.BlockHash, error) {
+	block, err := t.client.GetBlock(ctx, rpc.BlockLevel(level))
+	if err != nil {
+		return tzt.BlockHash{}, err
+	}
+	return block.Hash, nil
+}
+
+// scanBlock decodes every burn operation addressed to the bridge contract at
+// the given level into pending events.
+func (t *Tezos) scanBlock(ctx context.Context, level int64) ([]pendingEvent, error) {
+	block, err := t.client.GetBlock(ctx, rpc.BlockLevel(level))
+	if err != nil {
+		return nil, fmt.Errorf("fetch block %d: %w", level, err)
+	}
+
+	var events []pendingEvent
+	for _, pass := range block.Operations {
+		for _, op := range pass {
+			for _, content := range op.Contents {
+				transaction, ok := content.(*rpc.Transaction)
+				if !ok || transaction.Destination != t.contractAddr || transaction.Parameters == nil {
+					continue
+				}
+
+				var asset string
+				switch transaction.Parameters.Entrypoint {
+				case "burn_wavax":
+					asset = assetWAVAX
+				case "burn_wusdc":
+					asset = assetWUSDC
+				default:
+					continue
+				}
+
+				amount, destination, err := decodeBurnParams(transaction.Parameters.Value)
+				if err != nil {
+					t.logger.Errorf("decode %s burn params: %s", asset, err)
+					continue
+				}
+
+				events = append(events, pendingEvent{
+					asset: asset,
+					event: BurnEvent{
+						asset:       asset,
+						user:        transaction.Source.String(),
+						amount:      amount,
+						destination: destination,
+						blockHash:   block.Hash,
+						level:       level,
+						opHash:      op.Hash,
+					},
+				})
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// Mint mints wrapped asset into the bridge contract's own balance, ready to
+// be handed to the user by Transfer. asset must be one the bridge contract
+// wraps ("WAVAX" or "WUSDC").
+func (t *Tezos) Mint(ctx context.Context, asset string, amount *big.Int) (string, *big.Int, error) {
+	entrypoint, err := mintEntrypoint(asset)
+	if err != nil {
+		return "", nil, err
+	}
+	return t.call(ctx, entrypoint, amount, "")
+}
+
+// Transfer sends previously-minted wrapped asset to destination.
+func (t *Tezos) Transfer(ctx context.Context, asset, destination string, amount *big.Int) (string, *big.Int, error) {
+	entrypoint, err := transferEntrypoint(asset)
+	if err != nil {
+		return "", nil, err
+	}
+	return t.call(ctx, entrypoint, amount, destination)
+}
+
+// Unlock always fails: Tezos never holds a natively-locked balance to
+// release, it only mints and transfers wrapped assets.
+func (t *Tezos) Unlock(ctx context.Context, asset, destination string, amount *big.Int) (string, *big.Int, error) {
+	return "", nil, fmt.Errorf("tezos adapter does not unlock %q; it only mints and transfers", asset)
+}
+
+func mintEntrypoint(asset string) (string, error) {
+	switch asset {
+	case assetWAVAX:
+		return "mint_wavax", nil
+	case assetWUSDC:
+		return "mint_wusdc", nil
+	default:
+		return "", fmt.Errorf("tezos adapter: unsupported asset %q", asset)
+	}
+}
+
+func transferEntrypoint(asset string) (string, error) {
+	switch asset {
+	case assetWAVAX:
+		return "transfer_wavax", nil
+	case assetWUSDC:
+		return "transfer_wusdc", nil
+	default:
+		return "", fmt.Errorf("tezos adapter: unsupported asset %q", asset)
+	}
+}
+
+// call submits entrypoint(amount, destination) at a dynamically-estimated
+// fee/gas/storage limit, rebroadcasting at a bumped fee via
+// fees.SendWithReplacement if it stalls, so a baker-congestion spike can't
+// leave it stuck forever.
+func (t *Tezos) call(ctx context.Context, entrypoint string, amount *big.Int, destination string) (string, *big.Int, error) {
+	params := callParams(entrypoint, amount, destination)
+
+	// A federation-approved swap carries its signed Bundle into the call, so
+	// the approvals that authorized it land in the operation's own
+	// parameters. As on the Avalanche side, this is an on-chain audit trail;
+	// the contract is not expected to verify the Ed25519 signatures itself.
+	if bundle, ok := federation.BundleFromContext(ctx); ok {
+		approvals, err := json.Marshal(bundle.Approvals)
+		if err != nil {
+			return "", nil, fmt.Errorf("marshal approvals for %s: %w", entrypoint, err)
+		}
+		params = callParamsWithApprovals(entrypoint, amount, destination, approvals)
+	}
+
+	// The RPC exposes no standalone "next counter" lookup; the account's
+	// current counter comes back on its contract state, same as
+	// rpc.Client.Complete reads it for any other manager operation.
+	account, err := t.client.GetContractExt(ctx, t.address, rpc.Head)
+	if err != nil {
+		return "", nil, fmt.Errorf("fetch account state for %s: %w", entrypoint, err)
+	}
+	counter := account.Counter + 1
+
+	newOp := func(limits fees.TezosFees) *codec.Op {
+		content := &codec.Transaction{
+			Manager: codec.Manager{
+				Source:  t.address,
+				Counter: tzt.NewN(counter),
+			},
+			Destination: t.contractAddr,
+			Parameters:  params,
+		}
+
+		op := codec.NewOp().WithSource(t.address).WithTTL(tzt.DefaultParams.MaxOperationsTTL)
+		op.WithContents(content)
+		op.WithLimits([]tzt.Limits{{Fee: limits.Fee, GasLimit: limits.GasLimit, StorageLimit: limits.StorageLimit}}, 0)
+		return op
+	}
+
+	initial, err := fees.EstimateTezos(ctx, tezosSim{tezos: t, op: newOp(fees.TezosFees{GasLimit: simGasLimit, StorageLimit: simStorageLimit})})
+	if err != nil {
+		return "", nil, fmt.Errorf("estimate fees for %s: %w", entrypoint, err)
+	}
+
+	build := func(p fees.FeeParams) (fees.Tx, error) {
+		head, err := t.client.GetHeadBlock(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fetch head block: %w", err)
+		}
+
+		op := newOp(*p.Tezos).WithBranch(head.Hash)
+		if err := op.Sign(t.key); err != nil {
+			return nil, fmt.Errorf("sign %s: %w", entrypoint, err)
+		}
+
+		hash, err := t.client.Broadcast(ctx, op)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entrypoint, err)
+		}
+
+		return &tezosTx{tezos: t, hash: hash, fee: p.Tezos.Fee, fromLevel: head.Header.Level}, nil
+	}
+
+	return fees.SendWithReplacement(ctx, t.replacement, t.waitNextLevel, fees.FeeParams{Tezos: &initial}, build)
+}